@@ -0,0 +1,307 @@
+package approve
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xxyijixx/dootask-golang-sdk/internal/core"
+	ihttp "github.com/xxyijixx/dootask-golang-sdk/internal/http"
+	"github.com/xxyijixx/dootask-golang-sdk/sdkerr"
+	"github.com/xxyijixx/dootask-golang-sdk/types"
+)
+
+// Service 审批服务
+type Service struct {
+	client core.HTTPDoer
+}
+
+// New 创建审批服务实例
+func New(client core.HTTPDoer) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+// ==================== 流程定义 ====================
+
+// ProcDefs 01. 获取流程定义列表
+// name 不为空时仅返回名称包含 name 的流程
+func (s *Service) ProcDefs(name string) ([]types.ApproveProcDef, error) {
+	return s.ProcDefsWithContext(context.Background(), name)
+}
+
+// ProcDefsWithContext 同 ProcDefs，ctx 用于控制请求的取消与超时
+func (s *Service) ProcDefsWithContext(ctx context.Context, name string) ([]types.ApproveProcDef, error) {
+	req := types.ApproveProcDefListRequest{Name: name}
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/approve/procdef/all", Params: req, Idempotent: true})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result []types.ApproveProcDef
+	err = ihttp.ParseAPIResponse(resp, &result)
+
+	if err != nil {
+		return nil, fmt.Errorf("API error: %w", err)
+	}
+
+	return result, nil
+}
+
+// SaveProcDef 02. 保存流程定义（管理员）
+// 同名流程已存在时发布新版本，进行中的审批仍按原版本流转
+func (s *Service) SaveProcDef(def *types.ApproveProcDef) (*types.ApproveProcDef, error) {
+	return s.SaveProcDefWithContext(context.Background(), def)
+}
+
+// SaveProcDefWithContext 同 SaveProcDef，ctx 用于控制请求的取消与超时
+func (s *Service) SaveProcDefWithContext(ctx context.Context, def *types.ApproveProcDef) (*types.ApproveProcDef, error) {
+	if def == nil || def.Name == "" {
+		return nil, &sdkerr.ValidationError{Fields: []string{"name"}, Msg: "process name is required"}
+	}
+	if def.Resource == nil || def.Resource.Type != types.ApproveNodeStart {
+		return nil, &sdkerr.ValidationError{Fields: []string{"resource"}, Msg: "process must begin with a start node"}
+	}
+
+	resp, err := s.client.Do(ctx, &core.Request{Method: "POST", Path: "/api/approve/procdef/save", Params: def})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result types.ApproveProcDef
+	err = ihttp.ParseAPIResponse(resp, &result)
+
+	if err != nil {
+		return nil, fmt.Errorf("API error: %w", err)
+	}
+
+	return &result, nil
+}
+
+// DeleteProcDef 03. 删除流程定义（管理员）
+// 进行中的审批不受影响
+func (s *Service) DeleteProcDef(id int) error {
+	return s.DeleteProcDefWithContext(context.Background(), id)
+}
+
+// DeleteProcDefWithContext 同 DeleteProcDef，ctx 用于控制请求的取消与超时
+func (s *Service) DeleteProcDefWithContext(ctx context.Context, id int) error {
+	req := types.ApproveProcDefDelRequest{ID: id}
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/approve/procdef/del", Params: req})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	err = ihttp.ParseAPIResponse[any](resp, nil)
+
+	if err != nil {
+		return fmt.Errorf("API error: %w", err)
+	}
+
+	return nil
+}
+
+// ==================== 审批实例 ====================
+
+// Start 04. 发起审批
+// 按 req.ProcName 指定的流程发起，返回新建的审批实例
+func (s *Service) Start(req *types.ApproveStartRequest) (*types.ApproveInstance, error) {
+	return s.StartWithContext(context.Background(), req)
+}
+
+// StartWithContext 同 Start，ctx 用于控制请求的取消与超时
+func (s *Service) StartWithContext(ctx context.Context, req *types.ApproveStartRequest) (*types.ApproveInstance, error) {
+	if req == nil || req.ProcName == "" {
+		return nil, &sdkerr.ValidationError{Fields: []string{"proc_name"}, Msg: "process name is required"}
+	}
+	if req.Var.Type == "" {
+		return nil, &sdkerr.ValidationError{Fields: []string{"var"}, Msg: "approval type is required"}
+	}
+
+	resp, err := s.client.Do(ctx, &core.Request{Method: "POST", Path: "/api/approve/process/start", Params: req})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result types.ApproveInstance
+	err = ihttp.ParseAPIResponse(resp, &result)
+
+	if err != nil {
+		return nil, fmt.Errorf("API error: %w", err)
+	}
+
+	return &result, nil
+}
+
+// Pending 05. 待我审批的列表
+// 返回当前节点需要我审批的审批实例，其 TaskID 用于 Approve、Reject
+func (s *Service) Pending(req *types.ApproveListRequest) (*types.ApproveListResponse, error) {
+	return s.PendingWithContext(context.Background(), req)
+}
+
+// PendingWithContext 同 Pending，ctx 用于控制请求的取消与超时
+func (s *Service) PendingWithContext(ctx context.Context, req *types.ApproveListRequest) (*types.ApproveListResponse, error) {
+	return s.list(ctx, "/api/approve/process/findTask", req)
+}
+
+// Started 06. 我发起的审批列表
+func (s *Service) Started(req *types.ApproveListRequest) (*types.ApproveListResponse, error) {
+	return s.StartedWithContext(context.Background(), req)
+}
+
+// StartedWithContext 同 Started，ctx 用于控制请求的取消与超时
+func (s *Service) StartedWithContext(ctx context.Context, req *types.ApproveListRequest) (*types.ApproveListResponse, error) {
+	return s.list(ctx, "/api/approve/process/startByMyself", req)
+}
+
+// Done 07. 我已审批的列表
+// 返回我同意或拒绝过的审批实例，可用 History 查看审批记录
+func (s *Service) Done(req *types.ApproveListRequest) (*types.ApproveListResponse, error) {
+	return s.DoneWithContext(context.Background(), req)
+}
+
+// DoneWithContext 同 Done，ctx 用于控制请求的取消与超时
+func (s *Service) DoneWithContext(ctx context.Context, req *types.ApproveListRequest) (*types.ApproveListResponse, error) {
+	return s.list(ctx, "/api/approve/process/doneList", req)
+}
+
+// list 获取审批列表
+func (s *Service) list(ctx context.Context, path string, req *types.ApproveListRequest) (*types.ApproveListResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: path, Params: req, Idempotent: true})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result types.ApproveListResponse
+	err = ihttp.ParseAPIResponse(resp, &result)
+
+	if err != nil {
+		return nil, fmt.Errorf("API error: %w", err)
+	}
+
+	return &result, nil
+}
+
+// Detail 08. 获取审批详情
+// 包含流程节点和全部审批记录，仅发起人和流程中的审批人可查看
+func (s *Service) Detail(id int) (*types.ApproveInstance, error) {
+	return s.DetailWithContext(context.Background(), id)
+}
+
+// DetailWithContext 同 Detail，ctx 用于控制请求的取消与超时
+func (s *Service) DetailWithContext(ctx context.Context, id int) (*types.ApproveInstance, error) {
+	req := types.ApproveDetailRequest{ID: id}
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/approve/process/detail", Params: req, Idempotent: true})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result types.ApproveInstance
+	err = ihttp.ParseAPIResponse(resp, &result)
+
+	if err != nil {
+		return nil, fmt.Errorf("API error: %w", err)
+	}
+
+	return &result, nil
+}
+
+// History 获取审批记录
+// 在 Detail 的基础上仅返回审批记录，按操作时间正序
+func (s *Service) History(id int) ([]types.ApproveHistory, error) {
+	return s.HistoryWithContext(context.Background(), id)
+}
+
+// HistoryWithContext 同 History，ctx 用于控制请求的取消与超时
+func (s *Service) HistoryWithContext(ctx context.Context, id int) ([]types.ApproveHistory, error) {
+	detail, err := s.DetailWithContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return detail.History, nil
+}
+
+// ==================== 审批操作 ====================
+
+// Complete 09. 审批
+// 通用的审批接口，Approve、Reject 均基于此接口，返回审批后的实例
+func (s *Service) Complete(req *types.ApproveTaskCompleteRequest) (*types.ApproveInstance, error) {
+	return s.CompleteWithContext(context.Background(), req)
+}
+
+// CompleteWithContext 同 Complete，ctx 用于控制请求的取消与超时
+func (s *Service) CompleteWithContext(ctx context.Context, req *types.ApproveTaskCompleteRequest) (*types.ApproveInstance, error) {
+	if req == nil || req.TaskID == 0 {
+		return nil, &sdkerr.ValidationError{Fields: []string{"task_id"}, Msg: "task id is required"}
+	}
+	if req.Pass != "true" && req.Pass != "false" {
+		return nil, &sdkerr.ValidationError{Fields: []string{"pass"}, Msg: "pass must be true or false"}
+	}
+
+	resp, err := s.client.Do(ctx, &core.Request{Method: "POST", Path: "/api/approve/task/complete", Params: req})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result types.ApproveInstance
+	err = ihttp.ParseAPIResponse(resp, &result)
+
+	if err != nil {
+		return nil, fmt.Errorf("API error: %w", err)
+	}
+
+	return &result, nil
+}
+
+// Approve 同意审批
+// comment: 审批意见 (可选)
+func (s *Service) Approve(taskID int, comment string) (*types.ApproveInstance, error) {
+	return s.ApproveWithContext(context.Background(), taskID, comment)
+}
+
+// ApproveWithContext 同 Approve，ctx 用于控制请求的取消与超时
+func (s *Service) ApproveWithContext(ctx context.Context, taskID int, comment string) (*types.ApproveInstance, error) {
+	return s.CompleteWithContext(ctx, &types.ApproveTaskCompleteRequest{TaskID: taskID, Pass: "true", Comment: comment})
+}
+
+// Reject 拒绝审批
+// comment: 审批意见 (可选)；审批随即结束，状态为 types.ApproveStateRejected
+func (s *Service) Reject(taskID int, comment string) (*types.ApproveInstance, error) {
+	return s.RejectWithContext(context.Background(), taskID, comment)
+}
+
+// RejectWithContext 同 Reject，ctx 用于控制请求的取消与超时
+func (s *Service) RejectWithContext(ctx context.Context, taskID int, comment string) (*types.ApproveInstance, error) {
+	return s.CompleteWithContext(ctx, &types.ApproveTaskCompleteRequest{TaskID: taskID, Pass: "false", Comment: comment})
+}
+
+// Withdraw 10. 撤回审批
+// 仅发起人可撤回尚未结束的审批
+func (s *Service) Withdraw(taskID, procInstID int) error {
+	return s.WithdrawWithContext(context.Background(), taskID, procInstID)
+}
+
+// WithdrawWithContext 同 Withdraw，ctx 用于控制请求的取消与超时
+func (s *Service) WithdrawWithContext(ctx context.Context, taskID, procInstID int) error {
+	req := types.ApproveWithdrawRequest{TaskID: taskID, ProcInstID: procInstID}
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/approve/task/withdraw", Params: req})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	err = ihttp.ParseAPIResponse[any](resp, nil)
+
+	if err != nil {
+		return fmt.Errorf("API error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package approve
+
+import (
+	"context"
+	"iter"
+
+	"github.com/xxyijixx/dootask-golang-sdk/internal/paginate"
+	"github.com/xxyijixx/dootask-golang-sdk/types"
+)
+
+// AllPending 遍历待我审批列表的所有页，从 req.Page 开始（默认第 1 页），出错时产出错误并结束
+func (s *Service) AllPending(req *types.ApproveListRequest) iter.Seq2[types.ApproveInstance, error] {
+	return s.AllPendingWithContext(context.Background(), req)
+}
+
+// AllPendingWithContext 同 AllPending，ctx 用于控制请求的取消与超时
+func (s *Service) AllPendingWithContext(ctx context.Context, req *types.ApproveListRequest) iter.Seq2[types.ApproveInstance, error] {
+	return s.all(ctx, req, s.PendingWithContext)
+}
+
+// AllDone 遍历我已审批列表的所有页，从 req.Page 开始（默认第 1 页），出错时产出错误并结束
+func (s *Service) AllDone(req *types.ApproveListRequest) iter.Seq2[types.ApproveInstance, error] {
+	return s.AllDoneWithContext(context.Background(), req)
+}
+
+// AllDoneWithContext 同 AllDone，ctx 用于控制请求的取消与超时
+func (s *Service) AllDoneWithContext(ctx context.Context, req *types.ApproveListRequest) iter.Seq2[types.ApproveInstance, error] {
+	return s.all(ctx, req, s.DoneWithContext)
+}
+
+// all 按页遍历 list 返回的审批实例
+func (s *Service) all(ctx context.Context, req *types.ApproveListRequest, list func(context.Context, *types.ApproveListRequest) (*types.ApproveListResponse, error)) iter.Seq2[types.ApproveInstance, error] {
+	var r types.ApproveListRequest
+	if req != nil {
+		r = *req
+	}
+	return paginate.Pages(ctx, r.Page, func(ctx context.Context, page int) (*types.Page[types.ApproveInstance], error) {
+		r.Page = page
+		return list(ctx, &r)
+	})
+}
@@ -1,6 +1,7 @@
 package dialog
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/xxyijixx/dootask-golang-sdk/internal/core"
@@ -22,7 +23,12 @@ func New(client core.HTTPDoer) *Service {
 
 // GetDialogList 01.对话列表
 func (s *Service) GetDialogList(req *types.DialogListsRequest) (*types.DialogListsResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/lists", req)
+	return s.GetDialogListWithContext(context.Background(), req)
+}
+
+// GetDialogListWithContext 同 GetDialogList，ctx 用于控制请求的取消与超时
+func (s *Service) GetDialogListWithContext(ctx context.Context, req *types.DialogListsRequest) (*types.DialogListsResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/lists", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -32,7 +38,7 @@ func (s *Service) GetDialogList(req *types.DialogListsRequest) (*types.DialogLis
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -40,7 +46,12 @@ func (s *Service) GetDialogList(req *types.DialogListsRequest) (*types.DialogLis
 
 // SearchDialog 2.搜索会话
 func (s *Service) SearchDialog(req *types.SearchDialogRequest) (*types.SearchDialogResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/search", req)
+	return s.SearchDialogWithContext(context.Background(), req)
+}
+
+// SearchDialogWithContext 同 SearchDialog，ctx 用于控制请求的取消与超时
+func (s *Service) SearchDialogWithContext(ctx context.Context, req *types.SearchDialogRequest) (*types.SearchDialogResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/search", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -50,7 +61,7 @@ func (s *Service) SearchDialog(req *types.SearchDialogRequest) (*types.SearchDia
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -58,7 +69,12 @@ func (s *Service) SearchDialog(req *types.SearchDialogRequest) (*types.SearchDia
 
 // GetDialogDetail 3.获取单个会话信息
 func (s *Service) GetDialogDetail(req *types.DialogOneRequest) (*types.DialogOneResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/one", req)
+	return s.GetDialogDetailWithContext(context.Background(), req)
+}
+
+// GetDialogDetailWithContext 同 GetDialogDetail，ctx 用于控制请求的取消与超时
+func (s *Service) GetDialogDetailWithContext(ctx context.Context, req *types.DialogOneRequest) (*types.DialogOneResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/one", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -68,7 +84,7 @@ func (s *Service) GetDialogDetail(req *types.DialogOneRequest) (*types.DialogOne
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -76,7 +92,12 @@ func (s *Service) GetDialogDetail(req *types.DialogOneRequest) (*types.DialogOne
 
 // GetDialogMembers 4.获取会话成员
 func (s *Service) GetDialogMembers(req *types.DialogUserRequest) (*types.DialogUserResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/user", req)
+	return s.GetDialogMembersWithContext(context.Background(), req)
+}
+
+// GetDialogMembersWithContext 同 GetDialogMembers，ctx 用于控制请求的取消与超时
+func (s *Service) GetDialogMembersWithContext(ctx context.Context, req *types.DialogUserRequest) (*types.DialogUserResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/user", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -86,7 +107,7 @@ func (s *Service) GetDialogMembers(req *types.DialogUserRequest) (*types.DialogU
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -94,7 +115,12 @@ func (s *Service) GetDialogMembers(req *types.DialogUserRequest) (*types.DialogU
 
 // GetDialogTodo 5.获取会话待办
 func (s *Service) GetDialogTodo(req *types.DialogTodoRequest) (*types.DialogTodoResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/todo", req)
+	return s.GetDialogTodoWithContext(context.Background(), req)
+}
+
+// GetDialogTodoWithContext 同 GetDialogTodo，ctx 用于控制请求的取消与超时
+func (s *Service) GetDialogTodoWithContext(ctx context.Context, req *types.DialogTodoRequest) (*types.DialogTodoResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/todo", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -104,7 +130,7 @@ func (s *Service) GetDialogTodo(req *types.DialogTodoRequest) (*types.DialogTodo
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -112,7 +138,12 @@ func (s *Service) GetDialogTodo(req *types.DialogTodoRequest) (*types.DialogTodo
 
 // TopDialog 6.会话置顶
 func (s *Service) TopDialog(req *types.DialogTopRequest) (*types.DialogTopResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/top", req)
+	return s.TopDialogWithContext(context.Background(), req)
+}
+
+// TopDialogWithContext 同 TopDialog，ctx 用于控制请求的取消与超时
+func (s *Service) TopDialogWithContext(ctx context.Context, req *types.DialogTopRequest) (*types.DialogTopResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/top", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -122,7 +153,7 @@ func (s *Service) TopDialog(req *types.DialogTopRequest) (*types.DialogTopRespon
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -130,7 +161,12 @@ func (s *Service) TopDialog(req *types.DialogTopRequest) (*types.DialogTopRespon
 
 // GetDialogTel 7.获取对方联系电话
 func (s *Service) GetDialogTel(req *types.DialogTelRequest) (*types.DialogTelResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/tel", req)
+	return s.GetDialogTelWithContext(context.Background(), req)
+}
+
+// GetDialogTelWithContext 同 GetDialogTel，ctx 用于控制请求的取消与超时
+func (s *Service) GetDialogTelWithContext(ctx context.Context, req *types.DialogTelRequest) (*types.DialogTelResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/tel", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -140,7 +176,7 @@ func (s *Service) GetDialogTel(req *types.DialogTelRequest) (*types.DialogTelRes
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -148,7 +184,12 @@ func (s *Service) GetDialogTel(req *types.DialogTelRequest) (*types.DialogTelRes
 
 // OpenDialog 8.打开会话
 func (s *Service) OpenDialog(req *types.OpenDialogRequest) (*types.OpenDialogResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/open/user", req)
+	return s.OpenDialogWithContext(context.Background(), req)
+}
+
+// OpenDialogWithContext 同 OpenDialog，ctx 用于控制请求的取消与超时
+func (s *Service) OpenDialogWithContext(ctx context.Context, req *types.OpenDialogRequest) (*types.OpenDialogResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/open/user", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -158,7 +199,7 @@ func (s *Service) OpenDialog(req *types.OpenDialogRequest) (*types.OpenDialogRes
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -166,7 +207,12 @@ func (s *Service) OpenDialog(req *types.OpenDialogRequest) (*types.OpenDialogRes
 
 // GetMessageList 9.获取消息列表
 func (s *Service) GetMessageList(req *types.MessageListRequest) (*types.MessageListResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/msg/list", req)
+	return s.GetMessageListWithContext(context.Background(), req)
+}
+
+// GetMessageListWithContext 同 GetMessageList，ctx 用于控制请求的取消与超时
+func (s *Service) GetMessageListWithContext(ctx context.Context, req *types.MessageListRequest) (*types.MessageListResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/msg/list", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -176,7 +222,7 @@ func (s *Service) GetMessageList(req *types.MessageListRequest) (*types.MessageL
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -184,7 +230,12 @@ func (s *Service) GetMessageList(req *types.MessageListRequest) (*types.MessageL
 
 // SearchMessage 10.搜索消息位置
 func (s *Service) SearchMessage(req *types.SearchMessageRequest) (*types.SearchMessageResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/msg/search", req)
+	return s.SearchMessageWithContext(context.Background(), req)
+}
+
+// SearchMessageWithContext 同 SearchMessage，ctx 用于控制请求的取消与超时
+func (s *Service) SearchMessageWithContext(ctx context.Context, req *types.SearchMessageRequest) (*types.SearchMessageResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/msg/search", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -194,7 +245,7 @@ func (s *Service) SearchMessage(req *types.SearchMessageRequest) (*types.SearchM
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -202,7 +253,12 @@ func (s *Service) SearchMessage(req *types.SearchMessageRequest) (*types.SearchM
 
 // GetMessageOne 11.获取单条消息
 func (s *Service) GetMessageOne(req *types.MessageOneRequest) (*types.MessageOneResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/msg/one", req)
+	return s.GetMessageOneWithContext(context.Background(), req)
+}
+
+// GetMessageOneWithContext 同 GetMessageOne，ctx 用于控制请求的取消与超时
+func (s *Service) GetMessageOneWithContext(ctx context.Context, req *types.MessageOneRequest) (*types.MessageOneResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/msg/one", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -212,7 +268,7 @@ func (s *Service) GetMessageOne(req *types.MessageOneRequest) (*types.MessageOne
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -220,7 +276,12 @@ func (s *Service) GetMessageOne(req *types.MessageOneRequest) (*types.MessageOne
 
 // ReadMessage 12.已读聊天消息
 func (s *Service) ReadMessage(req *types.ReadMessageRequest) (*types.ReadMessageResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/msg/read", req)
+	return s.ReadMessageWithContext(context.Background(), req)
+}
+
+// ReadMessageWithContext 同 ReadMessage，ctx 用于控制请求的取消与超时
+func (s *Service) ReadMessageWithContext(ctx context.Context, req *types.ReadMessageRequest) (*types.ReadMessageResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/msg/read", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -230,7 +291,7 @@ func (s *Service) ReadMessage(req *types.ReadMessageRequest) (*types.ReadMessage
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -238,7 +299,12 @@ func (s *Service) ReadMessage(req *types.ReadMessageRequest) (*types.ReadMessage
 
 // GetUnreadMessage 13.获取未读消息数据
 func (s *Service) GetUnreadMessage(req *types.UnreadMessageRequest) (*types.UnreadMessageResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/msg/unread", req)
+	return s.GetUnreadMessageWithContext(context.Background(), req)
+}
+
+// GetUnreadMessageWithContext 同 GetUnreadMessage，ctx 用于控制请求的取消与超时
+func (s *Service) GetUnreadMessageWithContext(ctx context.Context, req *types.UnreadMessageRequest) (*types.UnreadMessageResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/msg/unread", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -248,7 +314,7 @@ func (s *Service) GetUnreadMessage(req *types.UnreadMessageRequest) (*types.Unre
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -256,7 +322,12 @@ func (s *Service) GetUnreadMessage(req *types.UnreadMessageRequest) (*types.Unre
 
 // StreamMessage 14.通知成员监听消息
 func (s *Service) StreamMessage(req *types.StreamMessageRequest) (*types.StreamMessageResponse, error) {
-	resp, err := s.client.DoRequest("POST", "/api/dialog/msg/stream", req)
+	return s.StreamMessageWithContext(context.Background(), req)
+}
+
+// StreamMessageWithContext 同 StreamMessage，ctx 用于控制请求的取消与超时
+func (s *Service) StreamMessageWithContext(ctx context.Context, req *types.StreamMessageRequest) (*types.StreamMessageResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "POST", Path: "/api/dialog/msg/stream", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -266,7 +337,7 @@ func (s *Service) StreamMessage(req *types.StreamMessageRequest) (*types.StreamM
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -274,7 +345,12 @@ func (s *Service) StreamMessage(req *types.StreamMessageRequest) (*types.StreamM
 
 // SendMessage 15.发送消息
 func (s *Service) SendMessage(req *types.SendMessageRequest) (*types.SendMessageResponse, error) {
-	resp, err := s.client.DoRequest("POST", "/api/dialog/msg/sendtext", req)
+	return s.SendMessageWithContext(context.Background(), req)
+}
+
+// SendMessageWithContext 同 SendMessage，ctx 用于控制请求的取消与超时
+func (s *Service) SendMessageWithContext(ctx context.Context, req *types.SendMessageRequest) (*types.SendMessageResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "POST", Path: "/api/dialog/msg/sendtext", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -284,7 +360,7 @@ func (s *Service) SendMessage(req *types.SendMessageRequest) (*types.SendMessage
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -292,7 +368,12 @@ func (s *Service) SendMessage(req *types.SendMessageRequest) (*types.SendMessage
 
 // SendRecord 16.发送语音
 func (s *Service) SendRecord(req *types.SendRecordRequest) (*types.SendRecordResponse, error) {
-	resp, err := s.client.DoRequest("POST", "/api/dialog/msg/sendrecord", req)
+	return s.SendRecordWithContext(context.Background(), req)
+}
+
+// SendRecordWithContext 同 SendRecord，ctx 用于控制请求的取消与超时
+func (s *Service) SendRecordWithContext(ctx context.Context, req *types.SendRecordRequest) (*types.SendRecordResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "POST", Path: "/api/dialog/msg/sendrecord", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -302,7 +383,7 @@ func (s *Service) SendRecord(req *types.SendRecordRequest) (*types.SendRecordRes
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -310,7 +391,12 @@ func (s *Service) SendRecord(req *types.SendRecordRequest) (*types.SendRecordRes
 
 // SendFile 17.文件上传
 func (s *Service) SendFile(req *types.SendFileRequest) (*types.SendFileResponse, error) {
-	resp, err := s.client.DoRequest("POST", "/api/dialog/msg/sendfile", req)
+	return s.SendFileWithContext(context.Background(), req)
+}
+
+// SendFileWithContext 同 SendFile，ctx 用于控制请求的取消与超时
+func (s *Service) SendFileWithContext(ctx context.Context, req *types.SendFileRequest) (*types.SendFileResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "POST", Path: "/api/dialog/msg/sendfile", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -320,7 +406,7 @@ func (s *Service) SendFile(req *types.SendFileRequest) (*types.SendFileResponse,
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -328,7 +414,12 @@ func (s *Service) SendFile(req *types.SendFileRequest) (*types.SendFileResponse,
 
 // SendFiles 18.群发文件上传
 func (s *Service) SendFiles(req *types.SendFilesRequest) (*types.SendFilesResponse, error) {
-	resp, err := s.client.DoRequest("POST", "/api/dialog/msg/sendfiles", req)
+	return s.SendFilesWithContext(context.Background(), req)
+}
+
+// SendFilesWithContext 同 SendFiles，ctx 用于控制请求的取消与超时
+func (s *Service) SendFilesWithContext(ctx context.Context, req *types.SendFilesRequest) (*types.SendFilesResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "POST", Path: "/api/dialog/msg/sendfiles", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -338,7 +429,7 @@ func (s *Service) SendFiles(req *types.SendFilesRequest) (*types.SendFilesRespon
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -346,7 +437,12 @@ func (s *Service) SendFiles(req *types.SendFilesRequest) (*types.SendFilesRespon
 
 // SendFileId 19.通过文件ID发送文件
 func (s *Service) SendFileId(req *types.SendFileIDRequest) (*types.SendFileIDResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/msg/sendfileid", req)
+	return s.SendFileIdWithContext(context.Background(), req)
+}
+
+// SendFileIdWithContext 同 SendFileId，ctx 用于控制请求的取消与超时
+func (s *Service) SendFileIdWithContext(ctx context.Context, req *types.SendFileIDRequest) (*types.SendFileIDResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/msg/sendfileid", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -356,7 +452,7 @@ func (s *Service) SendFileId(req *types.SendFileIDRequest) (*types.SendFileIDRes
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -364,7 +460,12 @@ func (s *Service) SendFileId(req *types.SendFileIDRequest) (*types.SendFileIDRes
 
 // SendAnonMessage 20.发送匿名消息
 func (s *Service) SendAnonMessage(req *types.SendAnonRequest) (*types.SendAnonResponse, error) {
-	resp, err := s.client.DoRequest("POST", "/api/dialog/msg/sendanon", req)
+	return s.SendAnonMessageWithContext(context.Background(), req)
+}
+
+// SendAnonMessageWithContext 同 SendAnonMessage，ctx 用于控制请求的取消与超时
+func (s *Service) SendAnonMessageWithContext(ctx context.Context, req *types.SendAnonRequest) (*types.SendAnonResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "POST", Path: "/api/dialog/msg/sendanon", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -374,7 +475,7 @@ func (s *Service) SendAnonMessage(req *types.SendAnonRequest) (*types.SendAnonRe
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -382,7 +483,12 @@ func (s *Service) SendAnonMessage(req *types.SendAnonRequest) (*types.SendAnonRe
 
 // GetMessageReadList 21.获取消息阅读情况
 func (s *Service) GetMessageReadList(req *types.MessageReadListRequest) (*types.MessageReadListResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/msg/readlist", req)
+	return s.GetMessageReadListWithContext(context.Background(), req)
+}
+
+// GetMessageReadListWithContext 同 GetMessageReadList，ctx 用于控制请求的取消与超时
+func (s *Service) GetMessageReadListWithContext(ctx context.Context, req *types.MessageReadListRequest) (*types.MessageReadListResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/msg/readlist", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -392,7 +498,7 @@ func (s *Service) GetMessageReadList(req *types.MessageReadListRequest) (*types.
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -400,7 +506,12 @@ func (s *Service) GetMessageReadList(req *types.MessageReadListRequest) (*types.
 
 // GetMessageDetail 22.消息详情
 func (s *Service) GetMessageDetail(req *types.MessageDetailRequest) (*types.MessageDetailResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/msg/detail", req)
+	return s.GetMessageDetailWithContext(context.Background(), req)
+}
+
+// GetMessageDetailWithContext 同 GetMessageDetail，ctx 用于控制请求的取消与超时
+func (s *Service) GetMessageDetailWithContext(ctx context.Context, req *types.MessageDetailRequest) (*types.MessageDetailResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/msg/detail", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -410,7 +521,7 @@ func (s *Service) GetMessageDetail(req *types.MessageDetailRequest) (*types.Mess
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -418,7 +529,12 @@ func (s *Service) GetMessageDetail(req *types.MessageDetailRequest) (*types.Mess
 
 // DownloadFile 23.文件下载
 func (s *Service) DownloadFile(req *types.DownloadFileRequest) (*types.DownloadFileResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/msg/download", req)
+	return s.DownloadFileWithContext(context.Background(), req)
+}
+
+// DownloadFileWithContext 同 DownloadFile，ctx 用于控制请求的取消与超时
+func (s *Service) DownloadFileWithContext(ctx context.Context, req *types.DownloadFileRequest) (*types.DownloadFileResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/msg/download", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -428,7 +544,7 @@ func (s *Service) DownloadFile(req *types.DownloadFileRequest) (*types.DownloadF
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -436,7 +552,12 @@ func (s *Service) DownloadFile(req *types.DownloadFileRequest) (*types.DownloadF
 
 // WithdrawMessage 24.聊天消息撤回
 func (s *Service) WithdrawMessage(req *types.WithdrawMessageRequest) (*types.WithdrawMessageResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/msg/withdraw", req)
+	return s.WithdrawMessageWithContext(context.Background(), req)
+}
+
+// WithdrawMessageWithContext 同 WithdrawMessage，ctx 用于控制请求的取消与超时
+func (s *Service) WithdrawMessageWithContext(ctx context.Context, req *types.WithdrawMessageRequest) (*types.WithdrawMessageResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/msg/withdraw", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -446,7 +567,7 @@ func (s *Service) WithdrawMessage(req *types.WithdrawMessageRequest) (*types.Wit
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -454,7 +575,12 @@ func (s *Service) WithdrawMessage(req *types.WithdrawMessageRequest) (*types.Wit
 
 // MarkMessage 25.消息标记操作
 func (s *Service) MarkMessage(req *types.MarkMessageRequest) (*types.MarkMessageResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/msg/mark", req)
+	return s.MarkMessageWithContext(context.Background(), req)
+}
+
+// MarkMessageWithContext 同 MarkMessage，ctx 用于控制请求的取消与超时
+func (s *Service) MarkMessageWithContext(ctx context.Context, req *types.MarkMessageRequest) (*types.MarkMessageResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/msg/mark", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -464,7 +590,7 @@ func (s *Service) MarkMessage(req *types.MarkMessageRequest) (*types.MarkMessage
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -472,7 +598,12 @@ func (s *Service) MarkMessage(req *types.MarkMessageRequest) (*types.MarkMessage
 
 // SilenceMessage 26.消息免打扰
 func (s *Service) SilenceMessage(req *types.SilenceMessageRequest) (*types.SilenceMessageResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/msg/silence", req)
+	return s.SilenceMessageWithContext(context.Background(), req)
+}
+
+// SilenceMessageWithContext 同 SilenceMessage，ctx 用于控制请求的取消与超时
+func (s *Service) SilenceMessageWithContext(ctx context.Context, req *types.SilenceMessageRequest) (*types.SilenceMessageResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/msg/silence", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -482,7 +613,7 @@ func (s *Service) SilenceMessage(req *types.SilenceMessageRequest) (*types.Silen
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -490,7 +621,12 @@ func (s *Service) SilenceMessage(req *types.SilenceMessageRequest) (*types.Silen
 
 // ForwardMessage 27.转发消息给
 func (s *Service) ForwardMessage(req *types.ForwardMessageRequest) (*types.ForwardMessageResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/msg/forward", req)
+	return s.ForwardMessageWithContext(context.Background(), req)
+}
+
+// ForwardMessageWithContext 同 ForwardMessage，ctx 用于控制请求的取消与超时
+func (s *Service) ForwardMessageWithContext(ctx context.Context, req *types.ForwardMessageRequest) (*types.ForwardMessageResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/msg/forward", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -500,7 +636,7 @@ func (s *Service) ForwardMessage(req *types.ForwardMessageRequest) (*types.Forwa
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -508,7 +644,12 @@ func (s *Service) ForwardMessage(req *types.ForwardMessageRequest) (*types.Forwa
 
 // EmojiMessage 28.emoji回复
 func (s *Service) EmojiMessage(req *types.EmojiMessageRequest) (*types.EmojiMessageResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/msg/emoji", req)
+	return s.EmojiMessageWithContext(context.Background(), req)
+}
+
+// EmojiMessageWithContext 同 EmojiMessage，ctx 用于控制请求的取消与超时
+func (s *Service) EmojiMessageWithContext(ctx context.Context, req *types.EmojiMessageRequest) (*types.EmojiMessageResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/msg/emoji", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -518,7 +659,7 @@ func (s *Service) EmojiMessage(req *types.EmojiMessageRequest) (*types.EmojiMess
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -526,7 +667,12 @@ func (s *Service) EmojiMessage(req *types.EmojiMessageRequest) (*types.EmojiMess
 
 // TagMessage 29.标注/取消标注
 func (s *Service) TagMessage(req *types.TagMessageRequest) (*types.TagMessageResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/msg/tag", req)
+	return s.TagMessageWithContext(context.Background(), req)
+}
+
+// TagMessageWithContext 同 TagMessage，ctx 用于控制请求的取消与超时
+func (s *Service) TagMessageWithContext(ctx context.Context, req *types.TagMessageRequest) (*types.TagMessageResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/msg/tag", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -536,7 +682,7 @@ func (s *Service) TagMessage(req *types.TagMessageRequest) (*types.TagMessageRes
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -544,7 +690,12 @@ func (s *Service) TagMessage(req *types.TagMessageRequest) (*types.TagMessageRes
 
 // TodoMessage 30.设待办/取消待办
 func (s *Service) TodoMessage(req *types.TodoMessageRequest) (*types.TodoMessageResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/msg/todo", req)
+	return s.TodoMessageWithContext(context.Background(), req)
+}
+
+// TodoMessageWithContext 同 TodoMessage，ctx 用于控制请求的取消与超时
+func (s *Service) TodoMessageWithContext(ctx context.Context, req *types.TodoMessageRequest) (*types.TodoMessageResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/msg/todo", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -554,7 +705,7 @@ func (s *Service) TodoMessage(req *types.TodoMessageRequest) (*types.TodoMessage
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -562,7 +713,12 @@ func (s *Service) TodoMessage(req *types.TodoMessageRequest) (*types.TodoMessage
 
 // GetTodoListMessage 31.获取消息待办情况
 func (s *Service) GetTodoListMessage(req *types.TodoListMessageRequest) (*types.TodoListMessageResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/msg/todolist", req)
+	return s.GetTodoListMessageWithContext(context.Background(), req)
+}
+
+// GetTodoListMessageWithContext 同 GetTodoListMessage，ctx 用于控制请求的取消与超时
+func (s *Service) GetTodoListMessageWithContext(ctx context.Context, req *types.TodoListMessageRequest) (*types.TodoListMessageResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/msg/todolist", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -572,7 +728,7 @@ func (s *Service) GetTodoListMessage(req *types.TodoListMessageRequest) (*types.
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -580,7 +736,12 @@ func (s *Service) GetTodoListMessage(req *types.TodoListMessageRequest) (*types.
 
 // DoneTodo 32.完成待办
 func (s *Service) DoneTodo(req *types.DoneTodoRequest) (*types.DoneTodoResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/msg/done", req)
+	return s.DoneTodoWithContext(context.Background(), req)
+}
+
+// DoneTodoWithContext 同 DoneTodo，ctx 用于控制请求的取消与超时
+func (s *Service) DoneTodoWithContext(ctx context.Context, req *types.DoneTodoRequest) (*types.DoneTodoResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/msg/done", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -590,7 +751,7 @@ func (s *Service) DoneTodo(req *types.DoneTodoRequest) (*types.DoneTodoResponse,
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -598,7 +759,12 @@ func (s *Service) DoneTodo(req *types.DoneTodoRequest) (*types.DoneTodoResponse,
 
 // ColorMessage 33.设置颜色
 func (s *Service) ColorMessage(req *types.ColorMessageRequest) (*types.ColorMessageResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/msg/color", req)
+	return s.ColorMessageWithContext(context.Background(), req)
+}
+
+// ColorMessageWithContext 同 ColorMessage，ctx 用于控制请求的取消与超时
+func (s *Service) ColorMessageWithContext(ctx context.Context, req *types.ColorMessageRequest) (*types.ColorMessageResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/msg/color", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -608,7 +774,7 @@ func (s *Service) ColorMessage(req *types.ColorMessageRequest) (*types.ColorMess
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -616,7 +782,12 @@ func (s *Service) ColorMessage(req *types.ColorMessageRequest) (*types.ColorMess
 
 // CreateGroup 34.新增群组
 func (s *Service) CreateGroup(req *types.CreateGroupRequest) (*types.CreateGroupResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/group/add", req)
+	return s.CreateGroupWithContext(context.Background(), req)
+}
+
+// CreateGroupWithContext 同 CreateGroup，ctx 用于控制请求的取消与超时
+func (s *Service) CreateGroupWithContext(ctx context.Context, req *types.CreateGroupRequest) (*types.CreateGroupResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/group/add", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -626,7 +797,7 @@ func (s *Service) CreateGroup(req *types.CreateGroupRequest) (*types.CreateGroup
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -634,7 +805,12 @@ func (s *Service) CreateGroup(req *types.CreateGroupRequest) (*types.CreateGroup
 
 // EditGroup 35.修改群组
 func (s *Service) EditGroup(req *types.EditGroupRequest) (*types.EditGroupResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/group/edit", req)
+	return s.EditGroupWithContext(context.Background(), req)
+}
+
+// EditGroupWithContext 同 EditGroup，ctx 用于控制请求的取消与超时
+func (s *Service) EditGroupWithContext(ctx context.Context, req *types.EditGroupRequest) (*types.EditGroupResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/group/edit", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -644,7 +820,7 @@ func (s *Service) EditGroup(req *types.EditGroupRequest) (*types.EditGroupRespon
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -652,7 +828,12 @@ func (s *Service) EditGroup(req *types.EditGroupRequest) (*types.EditGroupRespon
 
 // AddGroupUser 36.添加群成员
 func (s *Service) AddGroupUser(req *types.AddGroupUserRequest) (*types.AddGroupUserResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/group/adduser", req)
+	return s.AddGroupUserWithContext(context.Background(), req)
+}
+
+// AddGroupUserWithContext 同 AddGroupUser，ctx 用于控制请求的取消与超时
+func (s *Service) AddGroupUserWithContext(ctx context.Context, req *types.AddGroupUserRequest) (*types.AddGroupUserResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/group/adduser", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -662,7 +843,7 @@ func (s *Service) AddGroupUser(req *types.AddGroupUserRequest) (*types.AddGroupU
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -670,7 +851,12 @@ func (s *Service) AddGroupUser(req *types.AddGroupUserRequest) (*types.AddGroupU
 
 // DelGroupUser 37.移出（退出）群成员
 func (s *Service) DelGroupUser(req *types.DelGroupUserRequest) (*types.DelGroupUserResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/group/deluser", req)
+	return s.DelGroupUserWithContext(context.Background(), req)
+}
+
+// DelGroupUserWithContext 同 DelGroupUser，ctx 用于控制请求的取消与超时
+func (s *Service) DelGroupUserWithContext(ctx context.Context, req *types.DelGroupUserRequest) (*types.DelGroupUserResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/group/deluser", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -680,7 +866,7 @@ func (s *Service) DelGroupUser(req *types.DelGroupUserRequest) (*types.DelGroupU
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -688,7 +874,12 @@ func (s *Service) DelGroupUser(req *types.DelGroupUserRequest) (*types.DelGroupU
 
 // TransferGroup 38.转让群组
 func (s *Service) TransferGroup(req *types.TransferGroupRequest) (*types.TransferGroupResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/group/transfer", req)
+	return s.TransferGroupWithContext(context.Background(), req)
+}
+
+// TransferGroupWithContext 同 TransferGroup，ctx 用于控制请求的取消与超时
+func (s *Service) TransferGroupWithContext(ctx context.Context, req *types.TransferGroupRequest) (*types.TransferGroupResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/group/transfer", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -698,7 +889,7 @@ func (s *Service) TransferGroup(req *types.TransferGroupRequest) (*types.Transfe
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -706,7 +897,12 @@ func (s *Service) TransferGroup(req *types.TransferGroupRequest) (*types.Transfe
 
 // DisbandGroup 39.解散群组
 func (s *Service) DisbandGroup(req *types.DisbandGroupRequest) (*types.DisbandGroupResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/group/disband", req)
+	return s.DisbandGroupWithContext(context.Background(), req)
+}
+
+// DisbandGroupWithContext 同 DisbandGroup，ctx 用于控制请求的取消与超时
+func (s *Service) DisbandGroupWithContext(ctx context.Context, req *types.DisbandGroupRequest) (*types.DisbandGroupResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/group/disband", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -716,7 +912,7 @@ func (s *Service) DisbandGroup(req *types.DisbandGroupRequest) (*types.DisbandGr
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -724,7 +920,12 @@ func (s *Service) DisbandGroup(req *types.DisbandGroupRequest) (*types.DisbandGr
 
 // SearchGroupUser 40.搜索个人群（仅限管理员）
 func (s *Service) SearchGroupUser(req *types.SearchGroupUserRequest) (*types.SearchGroupUserResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/dialog/group/searchuser", req)
+	return s.SearchGroupUserWithContext(context.Background(), req)
+}
+
+// SearchGroupUserWithContext 同 SearchGroupUser，ctx 用于控制请求的取消与超时
+func (s *Service) SearchGroupUserWithContext(ctx context.Context, req *types.SearchGroupUserRequest) (*types.SearchGroupUserResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/dialog/group/searchuser", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -734,7 +935,7 @@ func (s *Service) SearchGroupUser(req *types.SearchGroupUserRequest) (*types.Sea
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -742,7 +943,12 @@ func (s *Service) SearchGroupUser(req *types.SearchGroupUserRequest) (*types.Sea
 
 // CreateOkrDialog 41.创建OKR评论会话
 func (s *Service) CreateOkrDialog(req *types.CreateOkrDialogRequest) (*types.CreateOkrDialogResponse, error) {
-	resp, err := s.client.DoRequest("POST", "/api/dialog/okr/add", req)
+	return s.CreateOkrDialogWithContext(context.Background(), req)
+}
+
+// CreateOkrDialogWithContext 同 CreateOkrDialog，ctx 用于控制请求的取消与超时
+func (s *Service) CreateOkrDialogWithContext(ctx context.Context, req *types.CreateOkrDialogRequest) (*types.CreateOkrDialogResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "POST", Path: "/api/dialog/okr/add", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -752,7 +958,7 @@ func (s *Service) CreateOkrDialog(req *types.CreateOkrDialogRequest) (*types.Cre
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -760,7 +966,12 @@ func (s *Service) CreateOkrDialog(req *types.CreateOkrDialogRequest) (*types.Cre
 
 // PushOkrInfo 42.推送OKR相关信息
 func (s *Service) PushOkrInfo(req *types.PushOkrInfoRequest) (*types.PushOkrInfoResponse, error) {
-	resp, err := s.client.DoRequest("POST", "/api/dialog/okr/push", req)
+	return s.PushOkrInfoWithContext(context.Background(), req)
+}
+
+// PushOkrInfoWithContext 同 PushOkrInfo，ctx 用于控制请求的取消与超时
+func (s *Service) PushOkrInfoWithContext(ctx context.Context, req *types.PushOkrInfoRequest) (*types.PushOkrInfoResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "POST", Path: "/api/dialog/okr/push", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -770,7 +981,7 @@ func (s *Service) PushOkrInfo(req *types.PushOkrInfoRequest) (*types.PushOkrInfo
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -0,0 +1,96 @@
+package dialog
+
+import (
+	"cmp"
+	"context"
+	"iter"
+	"slices"
+
+	"github.com/xxyijixx/dootask-golang-sdk/internal/paginate"
+	"github.com/xxyijixx/dootask-golang-sdk/types"
+)
+
+// AllDialogs 遍历对话列表的所有页，从 req.Page 开始（默认第 1 页），出错时产出错误并结束
+func (s *Service) AllDialogs(req *types.DialogListsRequest) iter.Seq2[types.DialogItem, error] {
+	return s.AllDialogsWithContext(context.Background(), req)
+}
+
+// AllDialogsWithContext 同 AllDialogs，ctx 用于控制请求的取消与超时
+func (s *Service) AllDialogsWithContext(ctx context.Context, req *types.DialogListsRequest) iter.Seq2[types.DialogItem, error] {
+	var r types.DialogListsRequest
+	if req != nil {
+		r = *req
+	}
+	return paginate.Pages(ctx, r.Page, func(ctx context.Context, page int) (*types.Page[types.DialogItem], error) {
+		r.Page = page
+		resp, err := s.GetDialogListWithContext(ctx, &r)
+		if err != nil {
+			return nil, err
+		}
+		return &resp.Page, nil
+	})
+}
+
+// AllMessages 以消息ID为游标遍历对话中的消息，每次请求 req.Take 条（默认 50）
+// 设置 req.NextID 时从该消息之后由旧到新遍历；否则从 req.PrevID 之前（未设置时从最新一条）由新到旧遍历
+// req.PositionID 会被忽略，出错时产出错误并结束
+func (s *Service) AllMessages(req *types.MessageListRequest) iter.Seq2[types.MessageItem, error] {
+	return s.AllMessagesWithContext(context.Background(), req)
+}
+
+// AllMessagesWithContext 同 AllMessages，ctx 用于控制请求的取消与超时
+func (s *Service) AllMessagesWithContext(ctx context.Context, req *types.MessageListRequest) iter.Seq2[types.MessageItem, error] {
+	var r types.MessageListRequest
+	if req != nil {
+		r = *req
+	}
+	r.PositionID = 0
+	forward := r.NextID > 0
+
+	return func(yield func(types.MessageItem, error) bool) {
+		cursor := r.PrevID
+		if forward {
+			cursor = r.NextID
+		}
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(types.MessageItem{}, err)
+				return
+			}
+			if forward {
+				r.NextID = cursor
+			} else {
+				r.PrevID = cursor
+			}
+			resp, err := s.GetMessageListWithContext(ctx, &r)
+			if err != nil {
+				yield(types.MessageItem{}, err)
+				return
+			}
+
+			// Keep only messages past the cursor, in iteration order, so an
+			// inclusive cursor or an unordered batch never repeats a message
+			batch := slices.DeleteFunc(resp.List, func(m types.MessageItem) bool {
+				if forward {
+					return m.ID <= cursor
+				}
+				return cursor > 0 && m.ID >= cursor
+			})
+			if len(batch) == 0 {
+				return
+			}
+			slices.SortFunc(batch, func(a, b types.MessageItem) int {
+				if forward {
+					return cmp.Compare(a.ID, b.ID)
+				}
+				return cmp.Compare(b.ID, a.ID)
+			})
+			for _, m := range batch {
+				if !yield(m, nil) {
+					return
+				}
+			}
+			cursor = batch[len(batch)-1].ID
+		}
+	}
+}
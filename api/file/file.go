@@ -1,14 +1,16 @@
 package file
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"io"
 	nethtp "net/http"
 	"net/url"
 	"strconv"
 
 	"github.com/xxyijixx/dootask-golang-sdk/internal/core"
 	"github.com/xxyijixx/dootask-golang-sdk/internal/http"
+	"github.com/xxyijixx/dootask-golang-sdk/sdkerr"
 	"github.com/xxyijixx/dootask-golang-sdk/types"
 )
 
@@ -36,12 +38,17 @@ func New(client core.HTTPDoer) *Service {
 // 按父级目录浏览文件
 // pid: 父级ID (可选)
 func (s *Service) Lists(pid *int) ([]types.File, error) {
+	return s.ListsWithContext(context.Background(), pid)
+}
+
+// ListsWithContext 同 Lists，ctx 用于控制请求的取消与超时
+func (s *Service) ListsWithContext(ctx context.Context, pid *int) ([]types.File, error) {
 	params := url.Values{}
 	if pid != nil {
 		params.Set("pid", strconv.Itoa(*pid))
 	}
 
-	resp, err := s.client.DoRequest("GET", "/api/file/lists?"+params.Encode(), nil)
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/file/lists", Query: params, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -50,7 +57,7 @@ func (s *Service) Lists(pid *int) ([]types.File, error) {
 	var files []types.File
 	err = http.ParseAPIResponse[[]types.File](resp, &files)
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return files, nil
@@ -60,6 +67,11 @@ func (s *Service) Lists(pid *int) ([]types.File, error) {
 // 支持文件ID或链接码获取
 // id: 文件ID(int) 或 链接码(string)
 func (s *Service) One(id interface{}) (*types.File, error) {
+	return s.OneWithContext(context.Background(), id)
+}
+
+// OneWithContext 同 One，ctx 用于控制请求的取消与超时
+func (s *Service) OneWithContext(ctx context.Context, id interface{}) (*types.File, error) {
 	params := url.Values{}
 
 	switch v := id.(type) {
@@ -68,10 +80,10 @@ func (s *Service) One(id interface{}) (*types.File, error) {
 	case string:
 		params.Set("id", v)
 	default:
-		return nil, fmt.Errorf("invalid id type, must be int or string")
+		return nil, &sdkerr.ValidationError{Fields: []string{"id"}, Msg: "invalid id type, must be int or string"}
 	}
 
-	resp, err := s.client.DoRequest("GET", "/api/file/one?"+params.Encode(), nil)
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/file/one", Query: params, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +92,7 @@ func (s *Service) One(id interface{}) (*types.File, error) {
 	var file types.File
 	err = http.ParseAPIResponse(resp, &file)
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &file, nil
@@ -92,6 +104,11 @@ func (s *Service) One(id interface{}) (*types.File, error) {
 // key: 关键词 (可选)
 // take: 获取数量，默认50，最大100 (可选)
 func (s *Service) Search(link, key *string, take *int) ([]types.File, error) {
+	return s.SearchWithContext(context.Background(), link, key, take)
+}
+
+// SearchWithContext 同 Search，ctx 用于控制请求的取消与超时
+func (s *Service) SearchWithContext(ctx context.Context, link, key *string, take *int) ([]types.File, error) {
 	params := url.Values{}
 	if link != nil {
 		params.Set("link", *link)
@@ -103,7 +120,7 @@ func (s *Service) Search(link, key *string, take *int) ([]types.File, error) {
 		params.Set("take", strconv.Itoa(*take))
 	}
 
-	resp, err := s.client.DoRequest("GET", "/api/file/search?"+params.Encode(), nil)
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/file/search", Query: params, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -112,7 +129,7 @@ func (s *Service) Search(link, key *string, take *int) ([]types.File, error) {
 	var files []types.File
 	err = http.ParseAPIResponse(resp, &files)
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return files, nil
@@ -129,6 +146,11 @@ func (s *Service) Search(link, key *string, take *int) ([]types.File, error) {
 // id: 文件ID (修改时使用，可选)
 // pid: 父级ID (可选)
 func (s *Service) Add(name, fileType string, id, pid *int) (*types.File, error) {
+	return s.AddWithContext(context.Background(), name, fileType, id, pid)
+}
+
+// AddWithContext 同 Add，ctx 用于控制请求的取消与超时
+func (s *Service) AddWithContext(ctx context.Context, name, fileType string, id, pid *int) (*types.File, error) {
 	req := types.FileAddRequest{
 		Name: name,
 		Type: fileType,
@@ -136,7 +158,7 @@ func (s *Service) Add(name, fileType string, id, pid *int) (*types.File, error)
 		PID:  pid,
 	}
 
-	resp, err := s.client.DoRequest("GET", "/api/file/add", req)
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/file/add", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -145,7 +167,7 @@ func (s *Service) Add(name, fileType string, id, pid *int) (*types.File, error)
 	var file types.File
 	err = http.ParseAPIResponse(resp, &file)
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &file, nil
@@ -154,11 +176,16 @@ func (s *Service) Add(name, fileType string, id, pid *int) (*types.File, error)
 // Copy GET 05. 复制文件(夹)
 // id: 文件ID
 func (s *Service) Copy(id int) (*types.File, error) {
+	return s.CopyWithContext(context.Background(), id)
+}
+
+// CopyWithContext 同 Copy，ctx 用于控制请求的取消与超时
+func (s *Service) CopyWithContext(ctx context.Context, id int) (*types.File, error) {
 	req := types.FileCopyRequest{
 		ID: id,
 	}
 
-	resp, err := s.client.DoRequest("GET", "/api/file/copy", req)
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/file/copy", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -167,7 +194,7 @@ func (s *Service) Copy(id int) (*types.File, error) {
 	var file types.File
 	err = http.ParseAPIResponse(resp, &file)
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &file, nil
@@ -178,12 +205,17 @@ func (s *Service) Copy(id int) (*types.File, error) {
 // ids: 文件ID列表
 // pid: 移动到的文件夹ID
 func (s *Service) Move(ids []int, pid int) ([]types.File, error) {
+	return s.MoveWithContext(context.Background(), ids, pid)
+}
+
+// MoveWithContext 同 Move，ctx 用于控制请求的取消与超时
+func (s *Service) MoveWithContext(ctx context.Context, ids []int, pid int) ([]types.File, error) {
 	req := types.FileMoveRequest{
 		IDs: ids,
 		PID: pid,
 	}
 
-	resp, err := s.client.DoRequest("GET", "/api/file/move", req)
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/file/move", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -192,7 +224,7 @@ func (s *Service) Move(ids []int, pid int) ([]types.File, error) {
 	var files []types.File
 	err = http.ParseAPIResponse(resp, &files)
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return files, nil
@@ -202,11 +234,16 @@ func (s *Service) Move(ids []int, pid int) ([]types.File, error) {
 // 批量删除文件
 // ids: 文件ID列表
 func (s *Service) Remove(ids []int) ([]types.File, error) {
+	return s.RemoveWithContext(context.Background(), ids)
+}
+
+// RemoveWithContext 同 Remove，ctx 用于控制请求的取消与超时
+func (s *Service) RemoveWithContext(ctx context.Context, ids []int) ([]types.File, error) {
 	req := types.FileRemoveRequest{
 		IDs: ids,
 	}
 
-	resp, err := s.client.DoRequest("GET", "/api/file/remove", req)
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/file/remove", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -215,7 +252,7 @@ func (s *Service) Remove(ids []int) ([]types.File, error) {
 	var files []types.File
 	err = http.ParseAPIResponse(resp, &files)
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return files, nil
@@ -236,7 +273,13 @@ func (s *Service) Remove(ids []int) ([]types.File, error) {
 // onlyUpdateAt: 仅获取update_at字段 (yes/no, 可选)
 // down: 下载模式 (no/yes/preview, 可选)
 // historyID: 读取历史记录ID (可选)
+// 除仅获取更新时间外均返回 *http.Response，调用者需关闭其 Body
 func (s *Service) Content(id interface{}, onlyUpdateAt, down *string, historyID *int) (interface{}, error) {
+	return s.ContentWithContext(context.Background(), id, onlyUpdateAt, down, historyID)
+}
+
+// ContentWithContext 同 Content，ctx 用于控制请求的取消与超时
+func (s *Service) ContentWithContext(ctx context.Context, id interface{}, onlyUpdateAt, down *string, historyID *int) (interface{}, error) {
 	params := url.Values{}
 
 	switch v := id.(type) {
@@ -245,7 +288,7 @@ func (s *Service) Content(id interface{}, onlyUpdateAt, down *string, historyID
 	case string:
 		params.Set("id", v)
 	default:
-		return nil, fmt.Errorf("invalid id type, must be int or string")
+		return nil, &sdkerr.ValidationError{Fields: []string{"id"}, Msg: "invalid id type, must be int or string"}
 	}
 
 	if onlyUpdateAt != nil {
@@ -258,55 +301,52 @@ func (s *Service) Content(id interface{}, onlyUpdateAt, down *string, historyID
 		params.Set("history_id", strconv.Itoa(*historyID))
 	}
 
-	resp, err := s.client.DoRequest("GET", "/api/file/content?"+params.Encode(), nil)
+	// 预览、下载及文件内容均以原始响应返回，仅获取更新时间时解析数据
+	download := down != nil && (*down == "yes" || *down == "preview")
+	updateOnly := !download && onlyUpdateAt != nil && *onlyUpdateAt == "yes"
+
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/file/content", Query: params, Stream: !updateOnly, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	// 如果是预览或下载，直接返回响应
-	if down != nil && (*down == "yes" || *down == "preview") {
-		return resp, nil
-	}
 
 	// 仅获取更新时间
-	if onlyUpdateAt != nil && *onlyUpdateAt == "yes" {
-		var result struct {
-			Ret  int    `json:"ret"`
-			Msg  string `json:"msg"`
-			Data struct {
-				ID       int    `json:"id"`
-				UpdateAt string `json:"update_at"`
-			} `json:"data"`
-		}
+	if updateOnly {
+		defer resp.Body.Close()
 
-		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-			return nil, err
+		var data struct {
+			ID       int    `json:"id"`
+			UpdateAt string `json:"update_at"`
 		}
 
-		if result.Ret != 1 {
-			return nil, fmt.Errorf("API error: %s", result.Msg)
+		if err := http.ParseAPIResponse(resp, &data); err != nil {
+			return nil, fmt.Errorf("API error: %w", err)
 		}
 
-		return result.Data, nil
+		return data, nil
 	}
 
 	// 返回文件内容（可能是文件数据、文本内容等）
-	// 这里返回原始响应，让调用者自己处理
+	// 这里返回原始响应，由调用者处理并关闭 resp.Body
 	return resp, nil
 }
 
-// ContentSave GET 09. 保存文件内容
+// ContentSave POST 09. 保存文件内容
 // 通过Request Payload提交内容
 // id: 文件ID
 // content: 内容数据
 func (s *Service) ContentSave(id int, content interface{}) (*types.FileContent, error) {
+	return s.ContentSaveWithContext(context.Background(), id, content)
+}
+
+// ContentSaveWithContext 同 ContentSave，ctx 用于控制请求的取消与超时
+func (s *Service) ContentSaveWithContext(ctx context.Context, id int, content interface{}) (*types.FileContent, error) {
 	req := types.FileContentSaveRequest{
 		ID:      id,
 		Content: content,
 	}
 
-	resp, err := s.client.DoRequest("GET", "/api/file/content/save", req)
+	resp, err := s.client.Do(ctx, &core.Request{Method: "POST", Path: "/api/file/content/save", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -315,7 +355,7 @@ func (s *Service) ContentSave(id int, content interface{}) (*types.FileContent,
 	var fileContent types.FileContent
 	err = http.ParseAPIResponse(resp, &fileContent)
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &fileContent, nil
@@ -329,10 +369,15 @@ func (s *Service) ContentSave(id int, content interface{}) (*types.FileContent,
 // 获取Office协作token
 // id: 文件ID
 func (s *Service) OfficeToken(id int) (map[string]interface{}, error) {
+	return s.OfficeTokenWithContext(context.Background(), id)
+}
+
+// OfficeTokenWithContext 同 OfficeToken，ctx 用于控制请求的取消与超时
+func (s *Service) OfficeTokenWithContext(ctx context.Context, id int) (map[string]interface{}, error) {
 	params := url.Values{}
 	params.Set("id", strconv.Itoa(id))
 
-	resp, err := s.client.DoRequest("GET", "/api/file/office/token?"+params.Encode(), nil)
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/file/office/token", Query: params, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -341,23 +386,28 @@ func (s *Service) OfficeToken(id int) (map[string]interface{}, error) {
 	var result map[string]interface{}
 	err = http.ParseAPIResponse(resp, &result)
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return result, nil
 }
 
-// ContentOffice GET 11. 保存文件内容（office）
+// ContentOffice POST 11. 保存文件内容（office）
 // Office文档保存
 // id: 文件ID
 // content: Office内容数据
 func (s *Service) ContentOffice(id int, content interface{}) (map[string]interface{}, error) {
+	return s.ContentOfficeWithContext(context.Background(), id, content)
+}
+
+// ContentOfficeWithContext 同 ContentOffice，ctx 用于控制请求的取消与超时
+func (s *Service) ContentOfficeWithContext(ctx context.Context, id int, content interface{}) (map[string]interface{}, error) {
 	req := map[string]interface{}{
 		"id":      id,
 		"content": content,
 	}
 
-	resp, err := s.client.DoRequest("GET", "/api/file/content/office", req)
+	resp, err := s.client.Do(ctx, &core.Request{Method: "POST", Path: "/api/file/content/office", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -366,7 +416,7 @@ func (s *Service) ContentOffice(id int, content interface{}) (map[string]interfa
 	var result map[string]interface{}
 	err = http.ParseAPIResponse(resp, &result)
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return result, nil
@@ -382,13 +432,18 @@ func (s *Service) ContentOffice(id int, content interface{}) (map[string]interfa
 // cover: 覆盖已存在的文件 0不覆盖 1覆盖 (可选)
 // webkitRelativePath: 相对路径 (可选)
 func (s *Service) ContentUpload(pid, cover *int, webkitRelativePath *string) (map[string]interface{}, error) {
+	return s.ContentUploadWithContext(context.Background(), pid, cover, webkitRelativePath)
+}
+
+// ContentUploadWithContext 同 ContentUpload，ctx 用于控制请求的取消与超时
+func (s *Service) ContentUploadWithContext(ctx context.Context, pid, cover *int, webkitRelativePath *string) (map[string]interface{}, error) {
 	req := types.FileContentUploadRequest{
 		PID:                pid,
 		Cover:              cover,
 		WebkitRelativePath: webkitRelativePath,
 	}
 
-	resp, err := s.client.DoRequest("GET", "/api/file/content/upload", req)
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/file/content/upload", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -397,12 +452,52 @@ func (s *Service) ContentUpload(pid, cover *int, webkitRelativePath *string) (ma
 	var result map[string]interface{}
 	err = http.ParseAPIResponse(resp, &result)
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return result, nil
 }
 
+// Upload POST 12. 上传文件
+// 以 multipart/form-data 流式上传，文件内容不会整体读入内存
+// pid: 父级ID (可选)
+// cover: 覆盖已存在的文件 0不覆盖 1覆盖 (可选)
+// name: 文件名
+// content: 文件内容，为 io.Seeker（如 *os.File）时请求失败可重试
+func (s *Service) Upload(pid, cover *int, name string, content io.Reader) ([]types.File, error) {
+	return s.UploadWithContext(context.Background(), pid, cover, name, content)
+}
+
+// UploadWithContext 同 Upload，ctx 用于控制请求的取消与超时
+func (s *Service) UploadWithContext(ctx context.Context, pid, cover *int, name string, content io.Reader) ([]types.File, error) {
+	if name == "" {
+		return nil, &sdkerr.ValidationError{Fields: []string{"name"}, Msg: "file name is required"}
+	}
+
+	fields := url.Values{}
+	if pid != nil {
+		fields.Set("pid", strconv.Itoa(*pid))
+	}
+	if cover != nil {
+		fields.Set("cover", strconv.Itoa(*cover))
+	}
+	body := core.MultipartBody(fields, core.File{Field: "files", Name: name, Content: content})
+
+	resp, err := s.client.Do(ctx, &core.Request{Method: "POST", Path: "/api/file/content/upload", Body: body})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var files []types.File
+	err = http.ParseAPIResponse(resp, &files)
+	if err != nil {
+		return nil, fmt.Errorf("API error: %w", err)
+	}
+
+	return files, nil
+}
+
 // ============================================================
 // ⏱️ 版本历史管理
 // ============================================================
@@ -412,7 +507,12 @@ func (s *Service) ContentUpload(pid, cover *int, webkitRelativePath *string) (ma
 // id: 文件ID
 // page: 当前页，默认1 (可选)
 // pageSize: 每页显示数量，默认20，最大100 (可选)
-func (s *Service) ContentHistory(id int, page, pageSize *int) (map[string]interface{}, error) {
+func (s *Service) ContentHistory(id int, page, pageSize *int) (*types.FileContentHistoryResponse, error) {
+	return s.ContentHistoryWithContext(context.Background(), id, page, pageSize)
+}
+
+// ContentHistoryWithContext 同 ContentHistory，ctx 用于控制请求的取消与超时
+func (s *Service) ContentHistoryWithContext(ctx context.Context, id int, page, pageSize *int) (*types.FileContentHistoryResponse, error) {
 	params := url.Values{}
 	params.Set("id", strconv.Itoa(id))
 
@@ -423,19 +523,19 @@ func (s *Service) ContentHistory(id int, page, pageSize *int) (map[string]interf
 		params.Set("pagesize", strconv.Itoa(*pageSize))
 	}
 
-	resp, err := s.client.DoRequest("GET", "/api/file/content/history?"+params.Encode(), nil)
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/file/content/history", Query: params, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	var result map[string]interface{}
+	var result types.FileContentHistoryResponse
 	err = http.ParseAPIResponse(resp, &result)
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
-	return result, nil
+	return &result, nil
 }
 
 // ContentRestore GET 14. 恢复文件历史
@@ -443,12 +543,17 @@ func (s *Service) ContentHistory(id int, page, pageSize *int) (map[string]interf
 // id: 文件ID
 // historyID: 历史数据ID
 func (s *Service) ContentRestore(id, historyID int) error {
+	return s.ContentRestoreWithContext(context.Background(), id, historyID)
+}
+
+// ContentRestoreWithContext 同 ContentRestore，ctx 用于控制请求的取消与超时
+func (s *Service) ContentRestoreWithContext(ctx context.Context, id, historyID int) error {
 	req := types.FileContentRestoreRequest{
 		ID:        id,
 		HistoryID: historyID,
 	}
 
-	resp, err := s.client.DoRequest("GET", "/api/file/content/restore", req)
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/file/content/restore", Params: req})
 	if err != nil {
 		return err
 	}
@@ -461,7 +566,7 @@ func (s *Service) ContentRestore(id, historyID int) error {
 
 	err = http.ParseAPIResponse(resp, &result)
 	if err != nil {
-		return fmt.Errorf("API error: %s", err.Error())
+		return fmt.Errorf("API error: %w", err)
 	}
 
 	return nil
@@ -479,11 +584,16 @@ func (s *Service) ContentRestore(id, historyID int) error {
 // 查看文件共享状态
 // id: 文件ID
 func (s *Service) Share(id int) (map[string]interface{}, error) {
+	return s.ShareWithContext(context.Background(), id)
+}
+
+// ShareWithContext 同 Share，ctx 用于控制请求的取消与超时
+func (s *Service) ShareWithContext(ctx context.Context, id int) (map[string]interface{}, error) {
 	req := types.FileShareRequest{
 		ID: id,
 	}
 
-	resp, err := s.client.DoRequest("GET", "/api/file/share", req)
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/file/share", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -492,7 +602,7 @@ func (s *Service) Share(id int) (map[string]interface{}, error) {
 	var result map[string]interface{}
 	err = http.ParseAPIResponse(resp, &result)
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return result, nil
@@ -505,6 +615,11 @@ func (s *Service) Share(id int) (map[string]interface{}, error) {
 // permission: 共享方式 0只读 1读写 -1删除
 // force: 忽略提醒 0不忽略 1忽略 (可选)
 func (s *Service) ShareUpdate(id int, userIDs []int, permission int, force *int) (*types.File, error) {
+	return s.ShareUpdateWithContext(context.Background(), id, userIDs, permission, force)
+}
+
+// ShareUpdateWithContext 同 ShareUpdate，ctx 用于控制请求的取消与超时
+func (s *Service) ShareUpdateWithContext(ctx context.Context, id int, userIDs []int, permission int, force *int) (*types.File, error) {
 	req := types.FileShareUpdateRequest{
 		ID:         id,
 		UserIDs:    userIDs,
@@ -512,7 +627,7 @@ func (s *Service) ShareUpdate(id int, userIDs []int, permission int, force *int)
 		Force:      force,
 	}
 
-	resp, err := s.client.DoRequest("GET", "/api/file/share/update", req)
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/file/share/update", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -521,7 +636,7 @@ func (s *Service) ShareUpdate(id int, userIDs []int, permission int, force *int)
 	var file types.File
 	err = http.ParseAPIResponse(resp, &file)
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &file, nil
@@ -531,11 +646,16 @@ func (s *Service) ShareUpdate(id int, userIDs []int, permission int, force *int)
 // 退出他人共享的文件
 // id: 文件ID
 func (s *Service) ShareOut(id int) error {
+	return s.ShareOutWithContext(context.Background(), id)
+}
+
+// ShareOutWithContext 同 ShareOut，ctx 用于控制请求的取消与超时
+func (s *Service) ShareOutWithContext(ctx context.Context, id int) error {
 	req := types.FileShareOutRequest{
 		ID: id,
 	}
 
-	resp, err := s.client.DoRequest("GET", "/api/file/share/out", req)
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/file/share/out", Params: req})
 	if err != nil {
 		return err
 	}
@@ -548,7 +668,7 @@ func (s *Service) ShareOut(id int) error {
 
 	err = http.ParseAPIResponse(resp, &result)
 	if err != nil {
-		return fmt.Errorf("API error: %s", err.Error())
+		return fmt.Errorf("API error: %w", err)
 	}
 
 	return nil
@@ -564,13 +684,18 @@ func (s *Service) ShareOut(id int) error {
 // refresh: 刷新链接 no不刷新 yes刷新 (默认no)
 // guestAccess: 是否允许游客访问 no不允许 yes允许 (默认no)
 func (s *Service) Link(id int, refresh, guestAccess string) (*types.FileLink, error) {
+	return s.LinkWithContext(context.Background(), id, refresh, guestAccess)
+}
+
+// LinkWithContext 同 Link，ctx 用于控制请求的取消与超时
+func (s *Service) LinkWithContext(ctx context.Context, id int, refresh, guestAccess string) (*types.FileLink, error) {
 	req := types.FileLinkRequest{
 		ID:          id,
 		Refresh:     refresh,
 		GuestAccess: guestAccess,
 	}
 
-	resp, err := s.client.DoRequest("GET", "/api/file/link", req)
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/file/link", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -579,7 +704,7 @@ func (s *Service) Link(id int, refresh, guestAccess string) (*types.FileLink, er
 	var fileLink types.FileLink
 	err = http.ParseAPIResponse(resp, &fileLink)
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &fileLink, nil
@@ -594,6 +719,11 @@ func (s *Service) Link(id int, refresh, guestAccess string) (*types.FileLink, er
 // ids: 文件ID列表
 // name: 下载文件名 (可选)
 func (s *Service) DownloadPack(ids []int, name *string) (map[string]interface{}, error) {
+	return s.DownloadPackWithContext(context.Background(), ids, name)
+}
+
+// DownloadPackWithContext 同 DownloadPack，ctx 用于控制请求的取消与超时
+func (s *Service) DownloadPackWithContext(ctx context.Context, ids []int, name *string) (map[string]interface{}, error) {
 	req := types.FileDownloadPackRequest{
 		IDs: ids,
 	}
@@ -602,7 +732,7 @@ func (s *Service) DownloadPack(ids []int, name *string) (map[string]interface{},
 		req.Name = *name
 	}
 
-	resp, err := s.client.DoRequest("GET", "/api/file/download/pack", req)
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/file/download/pack", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -611,7 +741,7 @@ func (s *Service) DownloadPack(ids []int, name *string) (map[string]interface{},
 	var result map[string]interface{}
 	err = http.ParseAPIResponse(resp, &result)
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return result, nil
@@ -620,11 +750,17 @@ func (s *Service) DownloadPack(ids []int, name *string) (map[string]interface{},
 // DownloadConfirm GET 20. 确认下载
 // 下载确认
 // key: 下载密钥
+// 返回原始下载流，调用者需关闭 resp.Body
 func (s *Service) DownloadConfirm(key string) (*nethtp.Response, error) {
+	return s.DownloadConfirmWithContext(context.Background(), key)
+}
+
+// DownloadConfirmWithContext 同 DownloadConfirm，ctx 用于控制请求的取消与超时
+func (s *Service) DownloadConfirmWithContext(ctx context.Context, key string) (*nethtp.Response, error) {
 	params := url.Values{}
 	params.Set("key", key)
 
-	resp, err := s.client.DoRequest("GET", "/api/file/download/confirm?"+params.Encode(), nil)
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/file/download/confirm", Query: params, Stream: true, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
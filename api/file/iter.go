@@ -0,0 +1,23 @@
+package file
+
+import (
+	"context"
+	"iter"
+
+	"github.com/xxyijixx/dootask-golang-sdk/internal/paginate"
+	"github.com/xxyijixx/dootask-golang-sdk/types"
+)
+
+// AllContentHistory 遍历文件历史记录的所有页，出错时产出错误并结束
+// id: 文件ID
+// pageSize: 每页显示数量，默认20，最大100 (可选)
+func (s *Service) AllContentHistory(id int, pageSize *int) iter.Seq2[types.FileHistory, error] {
+	return s.AllContentHistoryWithContext(context.Background(), id, pageSize)
+}
+
+// AllContentHistoryWithContext 同 AllContentHistory，ctx 用于控制请求的取消与超时
+func (s *Service) AllContentHistoryWithContext(ctx context.Context, id int, pageSize *int) iter.Seq2[types.FileHistory, error] {
+	return paginate.Pages(ctx, 1, func(ctx context.Context, page int) (*types.Page[types.FileHistory], error) {
+		return s.ContentHistoryWithContext(ctx, id, &page, pageSize)
+	})
+}
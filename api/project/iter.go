@@ -0,0 +1,60 @@
+package project
+
+import (
+	"context"
+	"iter"
+
+	"github.com/xxyijixx/dootask-golang-sdk/internal/paginate"
+	"github.com/xxyijixx/dootask-golang-sdk/types"
+)
+
+// AllProjects 遍历项目列表的所有页，从 req.Page 开始（默认第 1 页），出错时产出错误并结束
+func (s *Service) AllProjects(req *types.ProjectListsRequest) iter.Seq2[types.ProjectInfo, error] {
+	return s.AllProjectsWithContext(context.Background(), req)
+}
+
+// AllProjectsWithContext 同 AllProjects，ctx 用于控制请求的取消与超时
+func (s *Service) AllProjectsWithContext(ctx context.Context, req *types.ProjectListsRequest) iter.Seq2[types.ProjectInfo, error] {
+	var r types.ProjectListsRequest
+	if req != nil {
+		r = *req
+	}
+	return paginate.Pages(ctx, r.Page, func(ctx context.Context, page int) (*types.Page[types.ProjectInfo], error) {
+		r.Page = page
+		return s.GetProjectListsWithContext(ctx, &r)
+	})
+}
+
+// AllTasks 遍历任务列表的所有页，从 req.Page 开始（默认第 1 页），出错时产出错误并结束
+func (s *Service) AllTasks(req *types.ProjectTaskListsRequest) iter.Seq2[types.TaskInfo, error] {
+	return s.AllTasksWithContext(context.Background(), req)
+}
+
+// AllTasksWithContext 同 AllTasks，ctx 用于控制请求的取消与超时
+func (s *Service) AllTasksWithContext(ctx context.Context, req *types.ProjectTaskListsRequest) iter.Seq2[types.TaskInfo, error] {
+	var r types.ProjectTaskListsRequest
+	if req != nil {
+		r = *req
+	}
+	return paginate.Pages(ctx, r.Page, func(ctx context.Context, page int) (*types.Page[types.TaskInfo], error) {
+		r.Page = page
+		return s.GetTaskListsWithContext(ctx, &r)
+	})
+}
+
+// AllLogs 遍历项目/任务日志的所有页，从 req.Page 开始（默认第 1 页），出错时产出错误并结束
+func (s *Service) AllLogs(req *types.ProjectLogListsRequest) iter.Seq2[types.LogInfo, error] {
+	return s.AllLogsWithContext(context.Background(), req)
+}
+
+// AllLogsWithContext 同 AllLogs，ctx 用于控制请求的取消与超时
+func (s *Service) AllLogsWithContext(ctx context.Context, req *types.ProjectLogListsRequest) iter.Seq2[types.LogInfo, error] {
+	var r types.ProjectLogListsRequest
+	if req != nil {
+		r = *req
+	}
+	return paginate.Pages(ctx, r.Page, func(ctx context.Context, page int) (*types.Page[types.LogInfo], error) {
+		r.Page = page
+		return s.GetLogListsWithContext(ctx, &r)
+	})
+}
@@ -1,6 +1,7 @@
 package project
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/xxyijixx/dootask-golang-sdk/internal/core"
@@ -24,7 +25,12 @@ func New(client core.HTTPDoer) *Service {
 
 // GetProjectLists 01. 获取项目列表
 func (s *Service) GetProjectLists(req *types.ProjectListsRequest) (*types.ProjectListsResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/lists", req)
+	return s.GetProjectListsWithContext(context.Background(), req)
+}
+
+// GetProjectListsWithContext 同 GetProjectLists，ctx 用于控制请求的取消与超时
+func (s *Service) GetProjectListsWithContext(ctx context.Context, req *types.ProjectListsRequest) (*types.ProjectListsResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/lists", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -34,7 +40,7 @@ func (s *Service) GetProjectLists(req *types.ProjectListsRequest) (*types.Projec
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -42,7 +48,12 @@ func (s *Service) GetProjectLists(req *types.ProjectListsRequest) (*types.Projec
 
 // GetProjectOne 02. 获取一个项目信息
 func (s *Service) GetProjectOne(req *types.ProjectOneRequest) (*types.ProjectOneResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/one", req)
+	return s.GetProjectOneWithContext(context.Background(), req)
+}
+
+// GetProjectOneWithContext 同 GetProjectOne，ctx 用于控制请求的取消与超时
+func (s *Service) GetProjectOneWithContext(ctx context.Context, req *types.ProjectOneRequest) (*types.ProjectOneResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/one", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -52,7 +63,7 @@ func (s *Service) GetProjectOne(req *types.ProjectOneRequest) (*types.ProjectOne
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -60,7 +71,12 @@ func (s *Service) GetProjectOne(req *types.ProjectOneRequest) (*types.ProjectOne
 
 // AddProject 03. 添加项目
 func (s *Service) AddProject(req *types.ProjectAddRequest) (*types.ProjectAddResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/add", req)
+	return s.AddProjectWithContext(context.Background(), req)
+}
+
+// AddProjectWithContext 同 AddProject，ctx 用于控制请求的取消与超时
+func (s *Service) AddProjectWithContext(ctx context.Context, req *types.ProjectAddRequest) (*types.ProjectAddResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/add", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -70,7 +86,7 @@ func (s *Service) AddProject(req *types.ProjectAddRequest) (*types.ProjectAddRes
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -78,7 +94,12 @@ func (s *Service) AddProject(req *types.ProjectAddRequest) (*types.ProjectAddRes
 
 // UpdateProject 04. 修改项目
 func (s *Service) UpdateProject(req *types.ProjectUpdateRequest) (*types.ProjectUpdateResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/update", req)
+	return s.UpdateProjectWithContext(context.Background(), req)
+}
+
+// UpdateProjectWithContext 同 UpdateProject，ctx 用于控制请求的取消与超时
+func (s *Service) UpdateProjectWithContext(ctx context.Context, req *types.ProjectUpdateRequest) (*types.ProjectUpdateResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/update", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -88,7 +109,7 @@ func (s *Service) UpdateProject(req *types.ProjectUpdateRequest) (*types.Project
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -96,7 +117,12 @@ func (s *Service) UpdateProject(req *types.ProjectUpdateRequest) (*types.Project
 
 // TransferProject 09. 移交项目
 func (s *Service) TransferProject(req *types.ProjectTransferRequest) (*types.ProjectTransferResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/transfer", req)
+	return s.TransferProjectWithContext(context.Background(), req)
+}
+
+// TransferProjectWithContext 同 TransferProject，ctx 用于控制请求的取消与超时
+func (s *Service) TransferProjectWithContext(ctx context.Context, req *types.ProjectTransferRequest) (*types.ProjectTransferResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/transfer", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -106,7 +132,7 @@ func (s *Service) TransferProject(req *types.ProjectTransferRequest) (*types.Pro
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -114,7 +140,12 @@ func (s *Service) TransferProject(req *types.ProjectTransferRequest) (*types.Pro
 
 // ExitProject 11. 退出项目
 func (s *Service) ExitProject(req *types.ProjectExitRequest) (*types.ProjectExitResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/exit", req)
+	return s.ExitProjectWithContext(context.Background(), req)
+}
+
+// ExitProjectWithContext 同 ExitProject，ctx 用于控制请求的取消与超时
+func (s *Service) ExitProjectWithContext(ctx context.Context, req *types.ProjectExitRequest) (*types.ProjectExitResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/exit", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -124,7 +155,7 @@ func (s *Service) ExitProject(req *types.ProjectExitRequest) (*types.ProjectExit
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -132,7 +163,12 @@ func (s *Service) ExitProject(req *types.ProjectExitRequest) (*types.ProjectExit
 
 // ArchivedProject 12. 归档项目
 func (s *Service) ArchivedProject(req *types.ProjectArchivedRequest) (*types.ProjectArchivedResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/archived", req)
+	return s.ArchivedProjectWithContext(context.Background(), req)
+}
+
+// ArchivedProjectWithContext 同 ArchivedProject，ctx 用于控制请求的取消与超时
+func (s *Service) ArchivedProjectWithContext(ctx context.Context, req *types.ProjectArchivedRequest) (*types.ProjectArchivedResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/archived", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -142,7 +178,7 @@ func (s *Service) ArchivedProject(req *types.ProjectArchivedRequest) (*types.Pro
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -150,7 +186,12 @@ func (s *Service) ArchivedProject(req *types.ProjectArchivedRequest) (*types.Pro
 
 // RemoveProject 13. 删除项目
 func (s *Service) RemoveProject(req *types.ProjectRemoveRequest) (*types.ProjectRemoveResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/remove", req)
+	return s.RemoveProjectWithContext(context.Background(), req)
+}
+
+// RemoveProjectWithContext 同 RemoveProject，ctx 用于控制请求的取消与超时
+func (s *Service) RemoveProjectWithContext(ctx context.Context, req *types.ProjectRemoveRequest) (*types.ProjectRemoveResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/remove", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -160,7 +201,7 @@ func (s *Service) RemoveProject(req *types.ProjectRemoveRequest) (*types.Project
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -168,7 +209,12 @@ func (s *Service) RemoveProject(req *types.ProjectRemoveRequest) (*types.Project
 
 // TopProject 43. 项目置顶
 func (s *Service) TopProject(req *types.ProjectTopRequest) (*types.ProjectTopResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/top", req)
+	return s.TopProjectWithContext(context.Background(), req)
+}
+
+// TopProjectWithContext 同 TopProject，ctx 用于控制请求的取消与超时
+func (s *Service) TopProjectWithContext(ctx context.Context, req *types.ProjectTopRequest) (*types.ProjectTopResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/top", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -178,7 +224,7 @@ func (s *Service) TopProject(req *types.ProjectTopRequest) (*types.ProjectTopRes
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -188,7 +234,12 @@ func (s *Service) TopProject(req *types.ProjectTopRequest) (*types.ProjectTopRes
 
 // ManageProjectUser 05. 修改项目成员
 func (s *Service) ManageProjectUser(req *types.ProjectUserRequest) (*types.ProjectUserResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/user", req)
+	return s.ManageProjectUserWithContext(context.Background(), req)
+}
+
+// ManageProjectUserWithContext 同 ManageProjectUser，ctx 用于控制请求的取消与超时
+func (s *Service) ManageProjectUserWithContext(ctx context.Context, req *types.ProjectUserRequest) (*types.ProjectUserResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/user", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -198,7 +249,7 @@ func (s *Service) ManageProjectUser(req *types.ProjectUserRequest) (*types.Proje
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -206,7 +257,12 @@ func (s *Service) ManageProjectUser(req *types.ProjectUserRequest) (*types.Proje
 
 // GetProjectInvite 06. 获取邀请链接
 func (s *Service) GetProjectInvite(req *types.ProjectInviteRequest) (*types.ProjectInviteResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/invite", req)
+	return s.GetProjectInviteWithContext(context.Background(), req)
+}
+
+// GetProjectInviteWithContext 同 GetProjectInvite，ctx 用于控制请求的取消与超时
+func (s *Service) GetProjectInviteWithContext(ctx context.Context, req *types.ProjectInviteRequest) (*types.ProjectInviteResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/invite", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -216,7 +272,7 @@ func (s *Service) GetProjectInvite(req *types.ProjectInviteRequest) (*types.Proj
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -224,7 +280,12 @@ func (s *Service) GetProjectInvite(req *types.ProjectInviteRequest) (*types.Proj
 
 // GetProjectInviteInfo 07. 通过邀请链接code获取项目信息
 func (s *Service) GetProjectInviteInfo(req *types.ProjectInviteInfoRequest) (*types.ProjectInviteInfoResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/invite/info", req)
+	return s.GetProjectInviteInfoWithContext(context.Background(), req)
+}
+
+// GetProjectInviteInfoWithContext 同 GetProjectInviteInfo，ctx 用于控制请求的取消与超时
+func (s *Service) GetProjectInviteInfoWithContext(ctx context.Context, req *types.ProjectInviteInfoRequest) (*types.ProjectInviteInfoResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/invite/info", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -234,7 +295,7 @@ func (s *Service) GetProjectInviteInfo(req *types.ProjectInviteInfoRequest) (*ty
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -242,7 +303,12 @@ func (s *Service) GetProjectInviteInfo(req *types.ProjectInviteInfoRequest) (*ty
 
 // JoinProjectByInvite 08. 通过邀请链接code加入项目
 func (s *Service) JoinProjectByInvite(req *types.ProjectInviteJoinRequest) (*types.ProjectInviteJoinResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/invite/join", req)
+	return s.JoinProjectByInviteWithContext(context.Background(), req)
+}
+
+// JoinProjectByInviteWithContext 同 JoinProjectByInvite，ctx 用于控制请求的取消与超时
+func (s *Service) JoinProjectByInviteWithContext(ctx context.Context, req *types.ProjectInviteJoinRequest) (*types.ProjectInviteJoinResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/invite/join", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -252,7 +318,7 @@ func (s *Service) JoinProjectByInvite(req *types.ProjectInviteJoinRequest) (*typ
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -262,7 +328,12 @@ func (s *Service) JoinProjectByInvite(req *types.ProjectInviteJoinRequest) (*typ
 
 // GetColumnLists 14. 获取任务列表
 func (s *Service) GetColumnLists(req *types.ProjectColumnListsRequest) (*types.ProjectColumnListsResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/column/lists", req)
+	return s.GetColumnListsWithContext(context.Background(), req)
+}
+
+// GetColumnListsWithContext 同 GetColumnLists，ctx 用于控制请求的取消与超时
+func (s *Service) GetColumnListsWithContext(ctx context.Context, req *types.ProjectColumnListsRequest) (*types.ProjectColumnListsResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/column/lists", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -272,7 +343,7 @@ func (s *Service) GetColumnLists(req *types.ProjectColumnListsRequest) (*types.P
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -280,7 +351,12 @@ func (s *Service) GetColumnLists(req *types.ProjectColumnListsRequest) (*types.P
 
 // AddColumn 15. 添加任务列表
 func (s *Service) AddColumn(req *types.ProjectColumnAddRequest) (*types.ProjectColumnAddResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/column/add", req)
+	return s.AddColumnWithContext(context.Background(), req)
+}
+
+// AddColumnWithContext 同 AddColumn，ctx 用于控制请求的取消与超时
+func (s *Service) AddColumnWithContext(ctx context.Context, req *types.ProjectColumnAddRequest) (*types.ProjectColumnAddResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/column/add", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -290,7 +366,7 @@ func (s *Service) AddColumn(req *types.ProjectColumnAddRequest) (*types.ProjectC
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -298,7 +374,12 @@ func (s *Service) AddColumn(req *types.ProjectColumnAddRequest) (*types.ProjectC
 
 // UpdateColumn 16. 修改任务列表
 func (s *Service) UpdateColumn(req *types.ProjectColumnUpdateRequest) (*types.ProjectColumnUpdateResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/column/update", req)
+	return s.UpdateColumnWithContext(context.Background(), req)
+}
+
+// UpdateColumnWithContext 同 UpdateColumn，ctx 用于控制请求的取消与超时
+func (s *Service) UpdateColumnWithContext(ctx context.Context, req *types.ProjectColumnUpdateRequest) (*types.ProjectColumnUpdateResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/column/update", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -308,7 +389,7 @@ func (s *Service) UpdateColumn(req *types.ProjectColumnUpdateRequest) (*types.Pr
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -316,7 +397,12 @@ func (s *Service) UpdateColumn(req *types.ProjectColumnUpdateRequest) (*types.Pr
 
 // RemoveColumn 17. 删除任务列表
 func (s *Service) RemoveColumn(req *types.ProjectColumnRemoveRequest) (*types.ProjectColumnRemoveResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/column/remove", req)
+	return s.RemoveColumnWithContext(context.Background(), req)
+}
+
+// RemoveColumnWithContext 同 RemoveColumn，ctx 用于控制请求的取消与超时
+func (s *Service) RemoveColumnWithContext(ctx context.Context, req *types.ProjectColumnRemoveRequest) (*types.ProjectColumnRemoveResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/column/remove", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -326,7 +412,7 @@ func (s *Service) RemoveColumn(req *types.ProjectColumnRemoveRequest) (*types.Pr
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -334,7 +420,12 @@ func (s *Service) RemoveColumn(req *types.ProjectColumnRemoveRequest) (*types.Pr
 
 // GetColumnOne 18. 获取任务列详细
 func (s *Service) GetColumnOne(req *types.ProjectColumnOneRequest) (*types.ProjectColumnOneResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/column/one", req)
+	return s.GetColumnOneWithContext(context.Background(), req)
+}
+
+// GetColumnOneWithContext 同 GetColumnOne，ctx 用于控制请求的取消与超时
+func (s *Service) GetColumnOneWithContext(ctx context.Context, req *types.ProjectColumnOneRequest) (*types.ProjectColumnOneResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/column/one", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -344,7 +435,7 @@ func (s *Service) GetColumnOne(req *types.ProjectColumnOneRequest) (*types.Proje
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -354,7 +445,12 @@ func (s *Service) GetColumnOne(req *types.ProjectColumnOneRequest) (*types.Proje
 
 // GetTaskLists 19. 任务列表
 func (s *Service) GetTaskLists(req *types.ProjectTaskListsRequest) (*types.ProjectTaskListsResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/task/lists", req)
+	return s.GetTaskListsWithContext(context.Background(), req)
+}
+
+// GetTaskListsWithContext 同 GetTaskLists，ctx 用于控制请求的取消与超时
+func (s *Service) GetTaskListsWithContext(ctx context.Context, req *types.ProjectTaskListsRequest) (*types.ProjectTaskListsResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/task/lists", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -364,7 +460,7 @@ func (s *Service) GetTaskLists(req *types.ProjectTaskListsRequest) (*types.Proje
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -372,7 +468,12 @@ func (s *Service) GetTaskLists(req *types.ProjectTaskListsRequest) (*types.Proje
 
 // GetTaskEasyLists 20. 任务列表-简单的
 func (s *Service) GetTaskEasyLists(req *types.ProjectTaskEasyListsRequest) (*types.ProjectTaskEasyListsResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/task/easylists", req)
+	return s.GetTaskEasyListsWithContext(context.Background(), req)
+}
+
+// GetTaskEasyListsWithContext 同 GetTaskEasyLists，ctx 用于控制请求的取消与超时
+func (s *Service) GetTaskEasyListsWithContext(ctx context.Context, req *types.ProjectTaskEasyListsRequest) (*types.ProjectTaskEasyListsResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/task/easylists", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -382,7 +483,7 @@ func (s *Service) GetTaskEasyLists(req *types.ProjectTaskEasyListsRequest) (*typ
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -390,7 +491,12 @@ func (s *Service) GetTaskEasyLists(req *types.ProjectTaskEasyListsRequest) (*typ
 
 // GetTaskOne 24. 获取单个任务信息
 func (s *Service) GetTaskOne(req *types.ProjectTaskOneRequest) (*types.ProjectTaskOneResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/task/one", req)
+	return s.GetTaskOneWithContext(context.Background(), req)
+}
+
+// GetTaskOneWithContext 同 GetTaskOne，ctx 用于控制请求的取消与超时
+func (s *Service) GetTaskOneWithContext(ctx context.Context, req *types.ProjectTaskOneRequest) (*types.ProjectTaskOneResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/task/one", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -400,7 +506,7 @@ func (s *Service) GetTaskOne(req *types.ProjectTaskOneRequest) (*types.ProjectTa
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -408,7 +514,12 @@ func (s *Service) GetTaskOne(req *types.ProjectTaskOneRequest) (*types.ProjectTa
 
 // GetTaskContent 25. 获取任务详细描述
 func (s *Service) GetTaskContent(req *types.ProjectTaskContentRequest) (*types.ProjectTaskContentResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/task/content", req)
+	return s.GetTaskContentWithContext(context.Background(), req)
+}
+
+// GetTaskContentWithContext 同 GetTaskContent，ctx 用于控制请求的取消与超时
+func (s *Service) GetTaskContentWithContext(ctx context.Context, req *types.ProjectTaskContentRequest) (*types.ProjectTaskContentResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/task/content", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -418,7 +529,7 @@ func (s *Service) GetTaskContent(req *types.ProjectTaskContentRequest) (*types.P
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -428,7 +539,12 @@ func (s *Service) GetTaskContent(req *types.ProjectTaskContentRequest) (*types.P
 
 // AddTask 30. 添加任务
 func (s *Service) AddTask(req *types.ProjectTaskAddRequest) (*types.ProjectTaskAddResponse, error) {
-	resp, err := s.client.DoRequest("POST", "/api/project/task/add", req)
+	return s.AddTaskWithContext(context.Background(), req)
+}
+
+// AddTaskWithContext 同 AddTask，ctx 用于控制请求的取消与超时
+func (s *Service) AddTaskWithContext(ctx context.Context, req *types.ProjectTaskAddRequest) (*types.ProjectTaskAddResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "POST", Path: "/api/project/task/add", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -438,7 +554,7 @@ func (s *Service) AddTask(req *types.ProjectTaskAddRequest) (*types.ProjectTaskA
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -446,7 +562,12 @@ func (s *Service) AddTask(req *types.ProjectTaskAddRequest) (*types.ProjectTaskA
 
 // AddSubTask 31. 添加子任务
 func (s *Service) AddSubTask(req *types.ProjectTaskAddSubRequest) (*types.ProjectTaskAddSubResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/task/addsub", req)
+	return s.AddSubTaskWithContext(context.Background(), req)
+}
+
+// AddSubTaskWithContext 同 AddSubTask，ctx 用于控制请求的取消与超时
+func (s *Service) AddSubTaskWithContext(ctx context.Context, req *types.ProjectTaskAddSubRequest) (*types.ProjectTaskAddSubResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/task/addsub", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -456,7 +577,7 @@ func (s *Service) AddSubTask(req *types.ProjectTaskAddSubRequest) (*types.Projec
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -464,7 +585,12 @@ func (s *Service) AddSubTask(req *types.ProjectTaskAddSubRequest) (*types.Projec
 
 // UpdateTask 32. 修改任务、子任务
 func (s *Service) UpdateTask(req *types.ProjectTaskUpdateRequest) (*types.ProjectTaskUpdateResponse, error) {
-	resp, err := s.client.DoRequest("POST", "/api/project/task/update", req)
+	return s.UpdateTaskWithContext(context.Background(), req)
+}
+
+// UpdateTaskWithContext 同 UpdateTask，ctx 用于控制请求的取消与超时
+func (s *Service) UpdateTaskWithContext(ctx context.Context, req *types.ProjectTaskUpdateRequest) (*types.ProjectTaskUpdateResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "POST", Path: "/api/project/task/update", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -474,7 +600,7 @@ func (s *Service) UpdateTask(req *types.ProjectTaskUpdateRequest) (*types.Projec
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -482,7 +608,12 @@ func (s *Service) UpdateTask(req *types.ProjectTaskUpdateRequest) (*types.Projec
 
 // GetTaskDialog 33. 创建/获取聊天室
 func (s *Service) GetTaskDialog(req *types.ProjectTaskDialogRequest) (*types.ProjectTaskDialogResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/task/dialog", req)
+	return s.GetTaskDialogWithContext(context.Background(), req)
+}
+
+// GetTaskDialogWithContext 同 GetTaskDialog，ctx 用于控制请求的取消与超时
+func (s *Service) GetTaskDialogWithContext(ctx context.Context, req *types.ProjectTaskDialogRequest) (*types.ProjectTaskDialogResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/task/dialog", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -492,7 +623,7 @@ func (s *Service) GetTaskDialog(req *types.ProjectTaskDialogRequest) (*types.Pro
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -500,7 +631,12 @@ func (s *Service) GetTaskDialog(req *types.ProjectTaskDialogRequest) (*types.Pro
 
 // ArchivedTask 34. 归档任务
 func (s *Service) ArchivedTask(req *types.ProjectTaskArchivedRequest) (*types.ProjectTaskArchivedResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/task/archived", req)
+	return s.ArchivedTaskWithContext(context.Background(), req)
+}
+
+// ArchivedTaskWithContext 同 ArchivedTask，ctx 用于控制请求的取消与超时
+func (s *Service) ArchivedTaskWithContext(ctx context.Context, req *types.ProjectTaskArchivedRequest) (*types.ProjectTaskArchivedResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/task/archived", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -510,7 +646,7 @@ func (s *Service) ArchivedTask(req *types.ProjectTaskArchivedRequest) (*types.Pr
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -518,7 +654,12 @@ func (s *Service) ArchivedTask(req *types.ProjectTaskArchivedRequest) (*types.Pr
 
 // RemoveTask 35. 删除任务
 func (s *Service) RemoveTask(req *types.ProjectTaskRemoveRequest) (*types.ProjectTaskRemoveResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/task/remove", req)
+	return s.RemoveTaskWithContext(context.Background(), req)
+}
+
+// RemoveTaskWithContext 同 RemoveTask，ctx 用于控制请求的取消与超时
+func (s *Service) RemoveTaskWithContext(ctx context.Context, req *types.ProjectTaskRemoveRequest) (*types.ProjectTaskRemoveResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/task/remove", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -528,7 +669,7 @@ func (s *Service) RemoveTask(req *types.ProjectTaskRemoveRequest) (*types.Projec
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -536,7 +677,12 @@ func (s *Service) RemoveTask(req *types.ProjectTaskRemoveRequest) (*types.Projec
 
 // ResetTaskFromLog 36. 根据日志重置任务
 func (s *Service) ResetTaskFromLog(req *types.ProjectTaskResetFromLogRequest) (*types.ProjectTaskResetFromLogResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/task/resetfromlog", req)
+	return s.ResetTaskFromLogWithContext(context.Background(), req)
+}
+
+// ResetTaskFromLogWithContext 同 ResetTaskFromLog，ctx 用于控制请求的取消与超时
+func (s *Service) ResetTaskFromLogWithContext(ctx context.Context, req *types.ProjectTaskResetFromLogRequest) (*types.ProjectTaskResetFromLogResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/task/resetfromlog", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -546,7 +692,7 @@ func (s *Service) ResetTaskFromLog(req *types.ProjectTaskResetFromLogRequest) (*
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -554,7 +700,12 @@ func (s *Service) ResetTaskFromLog(req *types.ProjectTaskResetFromLogRequest) (*
 
 // MoveTask 38. 任务移动
 func (s *Service) MoveTask(req *types.ProjectTaskMoveRequest) (*types.ProjectTaskMoveResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/task/move", req)
+	return s.MoveTaskWithContext(context.Background(), req)
+}
+
+// MoveTaskWithContext 同 MoveTask，ctx 用于控制请求的取消与超时
+func (s *Service) MoveTaskWithContext(ctx context.Context, req *types.ProjectTaskMoveRequest) (*types.ProjectTaskMoveResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/task/move", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -564,7 +715,7 @@ func (s *Service) MoveTask(req *types.ProjectTaskMoveRequest) (*types.ProjectTas
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -574,7 +725,12 @@ func (s *Service) MoveTask(req *types.ProjectTaskMoveRequest) (*types.ProjectTas
 
 // SortTask 10. 排序任务
 func (s *Service) SortTask(req *types.ProjectSortRequest) (*types.ProjectSortResponse, error) {
-	resp, err := s.client.DoRequest("POST", "/api/project/sort", req)
+	return s.SortTaskWithContext(context.Background(), req)
+}
+
+// SortTaskWithContext 同 SortTask，ctx 用于控制请求的取消与超时
+func (s *Service) SortTaskWithContext(ctx context.Context, req *types.ProjectSortRequest) (*types.ProjectSortResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "POST", Path: "/api/project/sort", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -584,7 +740,7 @@ func (s *Service) SortTask(req *types.ProjectSortRequest) (*types.ProjectSortRes
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -594,7 +750,12 @@ func (s *Service) SortTask(req *types.ProjectSortRequest) (*types.ProjectSortRes
 
 // GetTaskFiles 26. 获取任务文件列表
 func (s *Service) GetTaskFiles(req *types.ProjectTaskFilesRequest) (*types.ProjectTaskFilesResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/task/files", req)
+	return s.GetTaskFilesWithContext(context.Background(), req)
+}
+
+// GetTaskFilesWithContext 同 GetTaskFiles，ctx 用于控制请求的取消与超时
+func (s *Service) GetTaskFilesWithContext(ctx context.Context, req *types.ProjectTaskFilesRequest) (*types.ProjectTaskFilesResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/task/files", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -604,7 +765,7 @@ func (s *Service) GetTaskFiles(req *types.ProjectTaskFilesRequest) (*types.Proje
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -612,7 +773,12 @@ func (s *Service) GetTaskFiles(req *types.ProjectTaskFilesRequest) (*types.Proje
 
 // DeleteTaskFile 27. 删除任务文件
 func (s *Service) DeleteTaskFile(req *types.ProjectTaskFileDeleteRequest) (*types.ProjectTaskFileDeleteResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/task/filedelete", req)
+	return s.DeleteTaskFileWithContext(context.Background(), req)
+}
+
+// DeleteTaskFileWithContext 同 DeleteTaskFile，ctx 用于控制请求的取消与超时
+func (s *Service) DeleteTaskFileWithContext(ctx context.Context, req *types.ProjectTaskFileDeleteRequest) (*types.ProjectTaskFileDeleteResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/task/filedelete", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -622,7 +788,7 @@ func (s *Service) DeleteTaskFile(req *types.ProjectTaskFileDeleteRequest) (*type
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -630,7 +796,12 @@ func (s *Service) DeleteTaskFile(req *types.ProjectTaskFileDeleteRequest) (*type
 
 // GetTaskFileDetail 28. 获取任务文件详情
 func (s *Service) GetTaskFileDetail(req *types.ProjectTaskFileDetailRequest) (*types.ProjectTaskFileDetailResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/task/filedetail", req)
+	return s.GetTaskFileDetailWithContext(context.Background(), req)
+}
+
+// GetTaskFileDetailWithContext 同 GetTaskFileDetail，ctx 用于控制请求的取消与超时
+func (s *Service) GetTaskFileDetailWithContext(ctx context.Context, req *types.ProjectTaskFileDetailRequest) (*types.ProjectTaskFileDetailResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/task/filedetail", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -640,7 +811,7 @@ func (s *Service) GetTaskFileDetail(req *types.ProjectTaskFileDetailRequest) (*t
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -648,7 +819,12 @@ func (s *Service) GetTaskFileDetail(req *types.ProjectTaskFileDetailRequest) (*t
 
 // DownloadTaskFile 29. 下载任务文件
 func (s *Service) DownloadTaskFile(req *types.ProjectTaskFileDownRequest) (*types.ProjectTaskFileDownResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/task/filedown", req)
+	return s.DownloadTaskFileWithContext(context.Background(), req)
+}
+
+// DownloadTaskFileWithContext 同 DownloadTaskFile，ctx 用于控制请求的取消与超时
+func (s *Service) DownloadTaskFileWithContext(ctx context.Context, req *types.ProjectTaskFileDownRequest) (*types.ProjectTaskFileDownResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/task/filedown", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -658,7 +834,7 @@ func (s *Service) DownloadTaskFile(req *types.ProjectTaskFileDownRequest) (*type
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -668,7 +844,12 @@ func (s *Service) DownloadTaskFile(req *types.ProjectTaskFileDownRequest) (*type
 
 // GetTaskFlow 37. 任务工作流信息
 func (s *Service) GetTaskFlow(req *types.ProjectTaskFlowRequest) (*types.ProjectTaskFlowResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/task/flow", req)
+	return s.GetTaskFlowWithContext(context.Background(), req)
+}
+
+// GetTaskFlowWithContext 同 GetTaskFlow，ctx 用于控制请求的取消与超时
+func (s *Service) GetTaskFlowWithContext(ctx context.Context, req *types.ProjectTaskFlowRequest) (*types.ProjectTaskFlowResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/task/flow", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -678,7 +859,7 @@ func (s *Service) GetTaskFlow(req *types.ProjectTaskFlowRequest) (*types.Project
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -686,7 +867,12 @@ func (s *Service) GetTaskFlow(req *types.ProjectTaskFlowRequest) (*types.Project
 
 // GetFlowList 39. 工作流列表
 func (s *Service) GetFlowList(req *types.ProjectFlowListRequest) (*types.ProjectFlowListResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/flow/list", req)
+	return s.GetFlowListWithContext(context.Background(), req)
+}
+
+// GetFlowListWithContext 同 GetFlowList，ctx 用于控制请求的取消与超时
+func (s *Service) GetFlowListWithContext(ctx context.Context, req *types.ProjectFlowListRequest) (*types.ProjectFlowListResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/flow/list", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -696,7 +882,7 @@ func (s *Service) GetFlowList(req *types.ProjectFlowListRequest) (*types.Project
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -704,7 +890,12 @@ func (s *Service) GetFlowList(req *types.ProjectFlowListRequest) (*types.Project
 
 // SaveFlow 40. 保存工作流
 func (s *Service) SaveFlow(req *types.ProjectFlowSaveRequest) (*types.ProjectFlowSaveResponse, error) {
-	resp, err := s.client.DoRequest("POST", "/api/project/flow/save", req)
+	return s.SaveFlowWithContext(context.Background(), req)
+}
+
+// SaveFlowWithContext 同 SaveFlow，ctx 用于控制请求的取消与超时
+func (s *Service) SaveFlowWithContext(ctx context.Context, req *types.ProjectFlowSaveRequest) (*types.ProjectFlowSaveResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "POST", Path: "/api/project/flow/save", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -714,7 +905,7 @@ func (s *Service) SaveFlow(req *types.ProjectFlowSaveRequest) (*types.ProjectFlo
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -722,7 +913,12 @@ func (s *Service) SaveFlow(req *types.ProjectFlowSaveRequest) (*types.ProjectFlo
 
 // DeleteFlow 41. 删除工作流
 func (s *Service) DeleteFlow(req *types.ProjectFlowDeleteRequest) (*types.ProjectFlowDeleteResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/flow/delete", req)
+	return s.DeleteFlowWithContext(context.Background(), req)
+}
+
+// DeleteFlowWithContext 同 DeleteFlow，ctx 用于控制请求的取消与超时
+func (s *Service) DeleteFlowWithContext(ctx context.Context, req *types.ProjectFlowDeleteRequest) (*types.ProjectFlowDeleteResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/flow/delete", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -732,7 +928,7 @@ func (s *Service) DeleteFlow(req *types.ProjectFlowDeleteRequest) (*types.Projec
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -742,7 +938,12 @@ func (s *Service) DeleteFlow(req *types.ProjectFlowDeleteRequest) (*types.Projec
 
 // ExportTask 21. 导出任务（限管理员）
 func (s *Service) ExportTask(req *types.ProjectTaskExportRequest) (*types.ProjectTaskExportResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/task/export", req)
+	return s.ExportTaskWithContext(context.Background(), req)
+}
+
+// ExportTaskWithContext 同 ExportTask，ctx 用于控制请求的取消与超时
+func (s *Service) ExportTaskWithContext(ctx context.Context, req *types.ProjectTaskExportRequest) (*types.ProjectTaskExportResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/task/export", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -752,7 +953,7 @@ func (s *Service) ExportTask(req *types.ProjectTaskExportRequest) (*types.Projec
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -760,7 +961,12 @@ func (s *Service) ExportTask(req *types.ProjectTaskExportRequest) (*types.Projec
 
 // ExportOverdueTask 22. 导出超期任务（限管理员）
 func (s *Service) ExportOverdueTask(req *types.ProjectTaskExportOverdueRequest) (*types.ProjectTaskExportOverdueResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/task/exportoverdue", req)
+	return s.ExportOverdueTaskWithContext(context.Background(), req)
+}
+
+// ExportOverdueTaskWithContext 同 ExportOverdueTask，ctx 用于控制请求的取消与超时
+func (s *Service) ExportOverdueTaskWithContext(ctx context.Context, req *types.ProjectTaskExportOverdueRequest) (*types.ProjectTaskExportOverdueResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/task/exportoverdue", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -770,7 +976,7 @@ func (s *Service) ExportOverdueTask(req *types.ProjectTaskExportOverdueRequest)
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -778,7 +984,12 @@ func (s *Service) ExportOverdueTask(req *types.ProjectTaskExportOverdueRequest)
 
 // DownloadExportedTask 23. 下载导出的任务
 func (s *Service) DownloadExportedTask(req *types.ProjectTaskDownRequest) (*types.ProjectTaskDownResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/task/down", req)
+	return s.DownloadExportedTaskWithContext(context.Background(), req)
+}
+
+// DownloadExportedTaskWithContext 同 DownloadExportedTask，ctx 用于控制请求的取消与超时
+func (s *Service) DownloadExportedTaskWithContext(ctx context.Context, req *types.ProjectTaskDownRequest) (*types.ProjectTaskDownResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/task/down", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -788,7 +999,7 @@ func (s *Service) DownloadExportedTask(req *types.ProjectTaskDownRequest) (*type
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -798,7 +1009,12 @@ func (s *Service) DownloadExportedTask(req *types.ProjectTaskDownRequest) (*type
 
 // GetLogLists 42. 获取项目、任务日志
 func (s *Service) GetLogLists(req *types.ProjectLogListsRequest) (*types.ProjectLogListsResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/project/log/lists", req)
+	return s.GetLogListsWithContext(context.Background(), req)
+}
+
+// GetLogListsWithContext 同 GetLogLists，ctx 用于控制请求的取消与超时
+func (s *Service) GetLogListsWithContext(ctx context.Context, req *types.ProjectLogListsRequest) (*types.ProjectLogListsResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/project/log/lists", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -808,7 +1024,7 @@ func (s *Service) GetLogLists(req *types.ProjectLogListsRequest) (*types.Project
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
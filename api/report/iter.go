@@ -0,0 +1,43 @@
+package report
+
+import (
+	"context"
+	"iter"
+
+	"github.com/xxyijixx/dootask-golang-sdk/internal/paginate"
+	"github.com/xxyijixx/dootask-golang-sdk/types"
+)
+
+// AllMyReports 遍历我发送的汇报的所有页，从 req.Page 开始（默认第 1 页），出错时产出错误并结束
+func (s *Service) AllMyReports(req *types.ReportMyRequest) iter.Seq2[types.ReportInfo, error] {
+	return s.AllMyReportsWithContext(context.Background(), req)
+}
+
+// AllMyReportsWithContext 同 AllMyReports，ctx 用于控制请求的取消与超时
+func (s *Service) AllMyReportsWithContext(ctx context.Context, req *types.ReportMyRequest) iter.Seq2[types.ReportInfo, error] {
+	var r types.ReportMyRequest
+	if req != nil {
+		r = *req
+	}
+	return paginate.Pages(ctx, r.Page, func(ctx context.Context, page int) (*types.Page[types.ReportInfo], error) {
+		r.Page = page
+		return s.GetMyReportsWithContext(ctx, &r)
+	})
+}
+
+// AllReceiveReports 遍历我接收的汇报的所有页，从 req.Page 开始（默认第 1 页），出错时产出错误并结束
+func (s *Service) AllReceiveReports(req *types.ReportReceiveRequest) iter.Seq2[types.ReportInfo, error] {
+	return s.AllReceiveReportsWithContext(context.Background(), req)
+}
+
+// AllReceiveReportsWithContext 同 AllReceiveReports，ctx 用于控制请求的取消与超时
+func (s *Service) AllReceiveReportsWithContext(ctx context.Context, req *types.ReportReceiveRequest) iter.Seq2[types.ReportInfo, error] {
+	var r types.ReportReceiveRequest
+	if req != nil {
+		r = *req
+	}
+	return paginate.Pages(ctx, r.Page, func(ctx context.Context, page int) (*types.Page[types.ReportInfo], error) {
+		r.Page = page
+		return s.GetReceiveReportsWithContext(ctx, &r)
+	})
+}
@@ -1,6 +1,7 @@
 package report
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/xxyijixx/dootask-golang-sdk/internal/core"
@@ -25,7 +26,12 @@ func New(client core.HTTPDoer) *Service {
 // GetMyReports 01. 我发送的汇报
 // 查看我发送的所有汇报，支持按类型和时间搜索
 func (s *Service) GetMyReports(req *types.ReportMyRequest) (*types.ReportMyResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/report/my", req)
+	return s.GetMyReportsWithContext(context.Background(), req)
+}
+
+// GetMyReportsWithContext 同 GetMyReports，ctx 用于控制请求的取消与超时
+func (s *Service) GetMyReportsWithContext(ctx context.Context, req *types.ReportMyRequest) (*types.ReportMyResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/report/my", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -35,7 +41,7 @@ func (s *Service) GetMyReports(req *types.ReportMyRequest) (*types.ReportMyRespo
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -44,7 +50,12 @@ func (s *Service) GetMyReports(req *types.ReportMyRequest) (*types.ReportMyRespo
 // GetReceiveReports 02. 我接收的汇报
 // 查看我接收到的汇报，支持关键词、类型和时间搜索
 func (s *Service) GetReceiveReports(req *types.ReportReceiveRequest) (*types.ReportReceiveResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/report/receive", req)
+	return s.GetReceiveReportsWithContext(context.Background(), req)
+}
+
+// GetReceiveReportsWithContext 同 GetReceiveReports，ctx 用于控制请求的取消与超时
+func (s *Service) GetReceiveReportsWithContext(ctx context.Context, req *types.ReportReceiveRequest) (*types.ReportReceiveResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/report/receive", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +65,7 @@ func (s *Service) GetReceiveReports(req *types.ReportReceiveRequest) (*types.Rep
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -63,7 +74,12 @@ func (s *Service) GetReceiveReports(req *types.ReportReceiveRequest) (*types.Rep
 // GetReportDetail 05. 报告详情
 // 查看具体报告的详细信息，包括阅读状态等
 func (s *Service) GetReportDetail(req *types.ReportDetailRequest) (*types.ReportDetailResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/report/detail", req)
+	return s.GetReportDetailWithContext(context.Background(), req)
+}
+
+// GetReportDetailWithContext 同 GetReportDetail，ctx 用于控制请求的取消与超时
+func (s *Service) GetReportDetailWithContext(ctx context.Context, req *types.ReportDetailRequest) (*types.ReportDetailResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/report/detail", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -73,7 +89,7 @@ func (s *Service) GetReportDetail(req *types.ReportDetailRequest) (*types.Report
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -82,9 +98,14 @@ func (s *Service) GetReportDetail(req *types.ReportDetailRequest) (*types.Report
 // ==================== 报告创建与发送 ====================
 
 // StoreReport 03. 保存并发送工作汇报
-// 创建新报告或修改现有报告，支持设置标题、类型、内容和接收人
+// 创建新报告或修改现有报告，支持设置标题、类型、内容和接收人；内容较大，以请求体提交
 func (s *Service) StoreReport(req *types.ReportStoreRequest) (*types.ReportStoreResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/report/store", req)
+	return s.StoreReportWithContext(context.Background(), req)
+}
+
+// StoreReportWithContext 同 StoreReport，ctx 用于控制请求的取消与超时
+func (s *Service) StoreReportWithContext(ctx context.Context, req *types.ReportStoreRequest) (*types.ReportStoreResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "POST", Path: "/api/report/store", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -94,7 +115,7 @@ func (s *Service) StoreReport(req *types.ReportStoreRequest) (*types.ReportStore
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -103,7 +124,12 @@ func (s *Service) StoreReport(req *types.ReportStoreRequest) (*types.ReportStore
 // GenerateReportTemplate 04. 生成汇报模板
 // 自动生成报告模板，支持周报、日报类型，会自动填充相关任务
 func (s *Service) GenerateReportTemplate(req *types.ReportTemplateRequest) (*types.ReportTemplateResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/report/template", req)
+	return s.GenerateReportTemplateWithContext(context.Background(), req)
+}
+
+// GenerateReportTemplateWithContext 同 GenerateReportTemplate，ctx 用于控制请求的取消与超时
+func (s *Service) GenerateReportTemplateWithContext(ctx context.Context, req *types.ReportTemplateRequest) (*types.ReportTemplateResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/report/template", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -113,7 +139,7 @@ func (s *Service) GenerateReportTemplate(req *types.ReportTemplateRequest) (*typ
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -124,7 +150,12 @@ func (s *Service) GenerateReportTemplate(req *types.ReportTemplateRequest) (*typ
 // MarkReport 06. 标记已读/未读
 // 单个报告阅读状态标记，支持已读/未读切换
 func (s *Service) MarkReport(req *types.ReportMarkRequest) (*types.ReportMarkResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/report/mark", req)
+	return s.MarkReportWithContext(context.Background(), req)
+}
+
+// MarkReportWithContext 同 MarkReport，ctx 用于控制请求的取消与超时
+func (s *Service) MarkReportWithContext(ctx context.Context, req *types.ReportMarkRequest) (*types.ReportMarkResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/report/mark", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -134,7 +165,7 @@ func (s *Service) MarkReport(req *types.ReportMarkRequest) (*types.ReportMarkRes
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -143,7 +174,12 @@ func (s *Service) MarkReport(req *types.ReportMarkRequest) (*types.ReportMarkRes
 // MarkReportsRead 09. 批量标记汇报已读
 // 批量标记多个报告为已读状态
 func (s *Service) MarkReportsRead(req *types.ReportReadRequest) (*types.ReportReadResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/report/read", req)
+	return s.MarkReportsReadWithContext(context.Background(), req)
+}
+
+// MarkReportsReadWithContext 同 MarkReportsRead，ctx 用于控制请求的取消与超时
+func (s *Service) MarkReportsReadWithContext(ctx context.Context, req *types.ReportReadRequest) (*types.ReportReadResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/report/read", Params: req})
 	if err != nil {
 		return nil, err
 	}
@@ -153,7 +189,7 @@ func (s *Service) MarkReportsRead(req *types.ReportReadRequest) (*types.ReportRe
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -162,7 +198,12 @@ func (s *Service) MarkReportsRead(req *types.ReportReadRequest) (*types.ReportRe
 // GetUnreadReports 08. 获取未读
 // 获取未读报告统计，可按类型分组查看
 func (s *Service) GetUnreadReports(req *types.ReportUnreadRequest) (*types.ReportUnreadResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/report/unread", req)
+	return s.GetUnreadReportsWithContext(context.Background(), req)
+}
+
+// GetUnreadReportsWithContext 同 GetUnreadReports，ctx 用于控制请求的取消与超时
+func (s *Service) GetUnreadReportsWithContext(ctx context.Context, req *types.ReportUnreadRequest) (*types.ReportUnreadResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/report/unread", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -172,7 +213,7 @@ func (s *Service) GetUnreadReports(req *types.ReportUnreadRequest) (*types.Repor
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -183,7 +224,12 @@ func (s *Service) GetUnreadReports(req *types.ReportUnreadRequest) (*types.Repor
 // GetLastSubmitter 07. 获取最后一次提交的接收人
 // 获取上次提交的接收人信息，便于快速填写新报告的接收人
 func (s *Service) GetLastSubmitter(req *types.ReportLastSubmitterRequest) (*types.ReportLastSubmitterResponse, error) {
-	resp, err := s.client.DoRequest("GET", "/api/report/last_submitter", req)
+	return s.GetLastSubmitterWithContext(context.Background(), req)
+}
+
+// GetLastSubmitterWithContext 同 GetLastSubmitter，ctx 用于控制请求的取消与超时
+func (s *Service) GetLastSubmitterWithContext(ctx context.Context, req *types.ReportLastSubmitterRequest) (*types.ReportLastSubmitterResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/report/last_submitter", Params: req, Idempotent: true})
 	if err != nil {
 		return nil, err
 	}
@@ -193,7 +239,7 @@ func (s *Service) GetLastSubmitter(req *types.ReportLastSubmitterRequest) (*type
 	err = ihttp.ParseAPIResponse(resp, &result)
 
 	if err != nil {
-		return nil, fmt.Errorf("API error: %s", err.Error())
+		return nil, fmt.Errorf("API error: %w", err)
 	}
 
 	return &result, nil
@@ -0,0 +1,230 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/xxyijixx/dootask-golang-sdk/internal/core"
+	ihttp "github.com/xxyijixx/dootask-golang-sdk/internal/http"
+	"github.com/xxyijixx/dootask-golang-sdk/sdkerr"
+	"github.com/xxyijixx/dootask-golang-sdk/types"
+)
+
+// Service 系统服务
+type Service struct {
+	client core.HTTPDoer
+}
+
+// New 创建系统服务实例
+func New(client core.HTTPDoer) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+// ==================== 系统设置 ====================
+
+// Settings 01. 获取系统设置
+func (s *Service) Settings() (*types.SystemSetting, error) {
+	return s.SettingsWithContext(context.Background())
+}
+
+// SettingsWithContext 同 Settings，ctx 用于控制请求的取消与超时
+func (s *Service) SettingsWithContext(ctx context.Context) (*types.SystemSetting, error) {
+	req := types.SystemSettingRequest{Type: "get"}
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/system/setting", Params: req, Idempotent: true})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result types.SystemSetting
+	err = ihttp.ParseAPIResponse(resp, &result)
+
+	if err != nil {
+		return nil, fmt.Errorf("API error: %w", err)
+	}
+
+	return &result, nil
+}
+
+// UpdateSettings 01. 保存系统设置（管理员）
+// 仅修改 update 中非空的字段，其余设置保持不变，返回保存后的设置
+func (s *Service) UpdateSettings(update *types.SystemSetting) (*types.SystemSetting, error) {
+	return s.UpdateSettingsWithContext(context.Background(), update)
+}
+
+// UpdateSettingsWithContext 同 UpdateSettings，ctx 用于控制请求的取消与超时
+func (s *Service) UpdateSettingsWithContext(ctx context.Context, update *types.SystemSetting) (*types.SystemSetting, error) {
+	if update == nil {
+		return nil, &sdkerr.ValidationError{Fields: []string{"update"}, Msg: "settings to update are required"}
+	}
+
+	// 保存接口会覆盖全部设置，先读取当前设置再合并，以免丢失本 SDK 未建模的设置项
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/system/setting", Params: types.SystemSettingRequest{Type: "get"}, Idempotent: true})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var current map[string]interface{}
+	err = ihttp.ParseAPIResponse(resp, &current)
+	if err != nil {
+		return nil, fmt.Errorf("API error: %w", err)
+	}
+
+	b, err := json.Marshal(update)
+	if err != nil {
+		return nil, err
+	}
+	params := map[string]interface{}{}
+	for key, value := range current {
+		params[key] = value
+	}
+	if err := json.Unmarshal(b, &params); err != nil {
+		return nil, err
+	}
+	params["type"] = "save"
+
+	resp, err = s.client.Do(ctx, &core.Request{Method: "POST", Path: "/api/system/setting", Params: params})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result types.SystemSetting
+	err = ihttp.ParseAPIResponse(resp, &result)
+
+	if err != nil {
+		return nil, fmt.Errorf("API error: %w", err)
+	}
+
+	return &result, nil
+}
+
+// Features 获取功能开关
+// 在 Settings 的基础上归纳常用的功能开关
+func (s *Service) Features() (*types.SystemFeatures, error) {
+	return s.FeaturesWithContext(context.Background())
+}
+
+// FeaturesWithContext 同 Features，ctx 用于控制请求的取消与超时
+func (s *Service) FeaturesWithContext(ctx context.Context) (*types.SystemFeatures, error) {
+	setting, err := s.SettingsWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	features := setting.Features()
+	return &features, nil
+}
+
+// ==================== 任务优先级 ====================
+
+// Priorities 02. 获取任务优先级
+func (s *Service) Priorities() ([]types.SystemPriority, error) {
+	return s.PrioritiesWithContext(context.Background())
+}
+
+// PrioritiesWithContext 同 Priorities，ctx 用于控制请求的取消与超时
+func (s *Service) PrioritiesWithContext(ctx context.Context) ([]types.SystemPriority, error) {
+	return s.priorities(ctx, "GET", &types.SystemPriorityRequest{Type: "get"})
+}
+
+// SavePriorities 02. 保存任务优先级（管理员）
+// 以 list 替换全部优先级定义，返回保存后的列表
+func (s *Service) SavePriorities(list []types.SystemPriority) ([]types.SystemPriority, error) {
+	return s.SavePrioritiesWithContext(context.Background(), list)
+}
+
+// SavePrioritiesWithContext 同 SavePriorities，ctx 用于控制请求的取消与超时
+func (s *Service) SavePrioritiesWithContext(ctx context.Context, list []types.SystemPriority) ([]types.SystemPriority, error) {
+	if len(list) == 0 {
+		return nil, &sdkerr.ValidationError{Fields: []string{"list"}, Msg: "at least one priority is required"}
+	}
+	return s.priorities(ctx, "POST", &types.SystemPriorityRequest{Type: "save", List: list})
+}
+
+func (s *Service) priorities(ctx context.Context, method string, req *types.SystemPriorityRequest) ([]types.SystemPriority, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: method, Path: "/api/system/priority", Params: req, Idempotent: req.Type == "get"})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result []types.SystemPriority
+	err = ihttp.ParseAPIResponse(resp, &result)
+
+	if err != nil {
+		return nil, fmt.Errorf("API error: %w", err)
+	}
+
+	return result, nil
+}
+
+// ==================== 列表模板 ====================
+
+// ColumnTemplates 03. 获取列表模板
+// 新建项目时可选的任务列表模板
+func (s *Service) ColumnTemplates() ([]types.SystemColumnTemplate, error) {
+	return s.ColumnTemplatesWithContext(context.Background())
+}
+
+// ColumnTemplatesWithContext 同 ColumnTemplates，ctx 用于控制请求的取消与超时
+func (s *Service) ColumnTemplatesWithContext(ctx context.Context) ([]types.SystemColumnTemplate, error) {
+	return s.columnTemplates(ctx, "GET", &types.SystemColumnTemplateRequest{Type: "get"})
+}
+
+// SaveColumnTemplates 03. 保存列表模板（管理员）
+// 以 list 替换全部模板，返回保存后的列表
+func (s *Service) SaveColumnTemplates(list []types.SystemColumnTemplate) ([]types.SystemColumnTemplate, error) {
+	return s.SaveColumnTemplatesWithContext(context.Background(), list)
+}
+
+// SaveColumnTemplatesWithContext 同 SaveColumnTemplates，ctx 用于控制请求的取消与超时
+func (s *Service) SaveColumnTemplatesWithContext(ctx context.Context, list []types.SystemColumnTemplate) ([]types.SystemColumnTemplate, error) {
+	return s.columnTemplates(ctx, "POST", &types.SystemColumnTemplateRequest{Type: "save", List: list})
+}
+
+func (s *Service) columnTemplates(ctx context.Context, method string, req *types.SystemColumnTemplateRequest) ([]types.SystemColumnTemplate, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: method, Path: "/api/system/column/template", Params: req, Idempotent: req.Type == "get"})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result []types.SystemColumnTemplate
+	err = ihttp.ParseAPIResponse(resp, &result)
+
+	if err != nil {
+		return nil, fmt.Errorf("API error: %w", err)
+	}
+
+	return result, nil
+}
+
+// ==================== 版本信息 ====================
+
+// Version 04. 获取服务器版本
+// 可用 AtLeast 判断服务器是否支持某个版本引入的接口
+func (s *Service) Version() (*types.SystemVersion, error) {
+	return s.VersionWithContext(context.Background())
+}
+
+// VersionWithContext 同 Version，ctx 用于控制请求的取消与超时
+func (s *Service) VersionWithContext(ctx context.Context) (*types.SystemVersion, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/system/version", Idempotent: true})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result types.SystemVersion
+	err = ihttp.ParseAPIResponse(resp, &result)
+
+	if err != nil {
+		return nil, fmt.Errorf("API error: %w", err)
+	}
+
+	return &result, nil
+}
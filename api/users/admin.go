@@ -0,0 +1,203 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/xxyijixx/dootask-golang-sdk/internal/core"
+	ihttp "github.com/xxyijixx/dootask-golang-sdk/internal/http"
+	"github.com/xxyijixx/dootask-golang-sdk/sdkerr"
+	"github.com/xxyijixx/dootask-golang-sdk/types"
+)
+
+// ==================== 会员管理（管理员） ====================
+//
+// 以下接口仅限管理员调用。令牌没有管理员权限时返回的错误满足
+// errors.Is(err, sdkerr.ErrForbidden)。
+
+// adminError 标注因缺少管理员权限而失败的调用
+func adminError(err error) error {
+	if errors.Is(err, sdkerr.ErrForbidden) {
+		return fmt.Errorf("admin rights required: %w", err)
+	}
+	return fmt.Errorf("API error: %w", err)
+}
+
+// List 12. 会员列表（管理员）
+// 默认仅返回在职会员，可按身份、离职状态和部门筛选
+func (s *Service) List(req *types.UserListsRequest) (*types.UserListsResponse, error) {
+	return s.ListWithContext(context.Background(), req)
+}
+
+// ListWithContext 同 List，ctx 用于控制请求的取消与超时
+func (s *Service) ListWithContext(ctx context.Context, req *types.UserListsRequest) (*types.UserListsResponse, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/users/lists", Params: req, Idempotent: true})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result types.UserListsResponse
+	err = ihttp.ParseAPIResponse(resp, &result)
+
+	if err != nil {
+		return nil, adminError(err)
+	}
+
+	return &result, nil
+}
+
+// Create 13. 创建会员（管理员）
+// 批量创建会员，返回创建成功的会员信息；任一会员的邮箱已存在时全部不创建
+func (s *Service) Create(items ...types.UserImportItem) ([]types.UserInfo, error) {
+	return s.CreateWithContext(context.Background(), items...)
+}
+
+// CreateWithContext 同 Create，ctx 用于控制请求的取消与超时
+func (s *Service) CreateWithContext(ctx context.Context, items ...types.UserImportItem) ([]types.UserInfo, error) {
+	if len(items) == 0 {
+		return []types.UserInfo{}, nil
+	}
+	for _, item := range items {
+		if item.Email == "" || item.Password == "" {
+			return nil, &sdkerr.ValidationError{Fields: []string{"email", "password"}, Msg: "email and password are required"}
+		}
+	}
+
+	req := types.UserImportRequest{List: items}
+	resp, err := s.client.Do(ctx, &core.Request{Method: "POST", Path: "/api/users/import", Params: req})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result []types.UserInfo
+	err = ihttp.ParseAPIResponse(resp, &result)
+
+	if err != nil {
+		return nil, adminError(err)
+	}
+
+	return result, nil
+}
+
+// Operation 11. 操作会员（管理员）
+// 通用的会员操作接口，Disable、SetAdmin 等方法均基于此接口，返回操作后的会员信息；
+// 参数放在 POST 请求体中，重置的密码不会出现在访问日志里
+func (s *Service) Operation(req *types.UserOperationRequest) (*types.UserInfo, error) {
+	return s.OperationWithContext(context.Background(), req)
+}
+
+// OperationWithContext 同 Operation，ctx 用于控制请求的取消与超时
+func (s *Service) OperationWithContext(ctx context.Context, req *types.UserOperationRequest) (*types.UserInfo, error) {
+	if req == nil || req.UserID == 0 {
+		return nil, &sdkerr.ValidationError{Fields: []string{"userid"}, Msg: "user id is required"}
+	}
+	// omitempty would drop an empty list and turn the call into a silent no-op
+	if req.Type == types.UserOpDepartment && len(req.Department) == 0 {
+		return nil, &sdkerr.ValidationError{Fields: []string{"department"}, Msg: "at least one department is required"}
+	}
+
+	resp, err := s.client.Do(ctx, &core.Request{Method: "POST", Path: "/api/users/operation", Params: req})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result types.UserInfo
+	err = ihttp.ParseAPIResponse(resp, &result)
+
+	if err != nil {
+		return nil, adminError(err)
+	}
+
+	return &result, nil
+}
+
+// Disable 禁用会员（办理离职）
+// 会员的令牌随即失效；transferUserID 不为0时将其负责的项目、任务移交给该会员
+func (s *Service) Disable(userID, transferUserID int) (*types.UserInfo, error) {
+	return s.DisableWithContext(context.Background(), userID, transferUserID)
+}
+
+// DisableWithContext 同 Disable，ctx 用于控制请求的取消与超时
+func (s *Service) DisableWithContext(ctx context.Context, userID, transferUserID int) (*types.UserInfo, error) {
+	if transferUserID == userID && userID != 0 {
+		return nil, &sdkerr.ValidationError{Fields: []string{"transfer_userid"}, Msg: "cannot transfer to the disabled user"}
+	}
+	return s.OperationWithContext(ctx, &types.UserOperationRequest{
+		UserID:         userID,
+		Type:           types.UserOpSetDisable,
+		TransferUserID: transferUserID,
+	})
+}
+
+// Enable 启用已禁用的会员（恢复在职）
+func (s *Service) Enable(userID int) (*types.UserInfo, error) {
+	return s.EnableWithContext(context.Background(), userID)
+}
+
+// EnableWithContext 同 Enable，ctx 用于控制请求的取消与超时
+func (s *Service) EnableWithContext(ctx context.Context, userID int) (*types.UserInfo, error) {
+	return s.OperationWithContext(ctx, &types.UserOperationRequest{UserID: userID, Type: types.UserOpClearDisable})
+}
+
+// Delete 删除会员
+// reason: 删除原因 (可选)
+func (s *Service) Delete(userID int, reason string) error {
+	return s.DeleteWithContext(context.Background(), userID, reason)
+}
+
+// DeleteWithContext 同 Delete，ctx 用于控制请求的取消与超时
+func (s *Service) DeleteWithContext(ctx context.Context, userID int, reason string) error {
+	_, err := s.OperationWithContext(ctx, &types.UserOperationRequest{
+		UserID:       userID,
+		Type:         types.UserOpDelete,
+		DeleteReason: reason,
+	})
+	return err
+}
+
+// SetAdmin 设置或取消管理员身份
+func (s *Service) SetAdmin(userID int, admin bool) (*types.UserInfo, error) {
+	return s.SetAdminWithContext(context.Background(), userID, admin)
+}
+
+// SetAdminWithContext 同 SetAdmin，ctx 用于控制请求的取消与超时
+func (s *Service) SetAdminWithContext(ctx context.Context, userID int, admin bool) (*types.UserInfo, error) {
+	op := types.UserOpClearAdmin
+	if admin {
+		op = types.UserOpSetAdmin
+	}
+	return s.OperationWithContext(ctx, &types.UserOperationRequest{UserID: userID, Type: op})
+}
+
+// SetTemp 设置或取消临时帐号身份
+// 临时帐号只能查看与自己相关的项目和对话
+func (s *Service) SetTemp(userID int, temp bool) (*types.UserInfo, error) {
+	return s.SetTempWithContext(context.Background(), userID, temp)
+}
+
+// SetTempWithContext 同 SetTemp，ctx 用于控制请求的取消与超时
+func (s *Service) SetTempWithContext(ctx context.Context, userID int, temp bool) (*types.UserInfo, error) {
+	op := types.UserOpClearTemp
+	if temp {
+		op = types.UserOpSetTemp
+	}
+	return s.OperationWithContext(ctx, &types.UserOperationRequest{UserID: userID, Type: op})
+}
+
+// ResetPassword 重置会员密码
+// 会员的令牌随即失效，下次登录后需修改密码
+func (s *Service) ResetPassword(userID int, password string) (*types.UserInfo, error) {
+	return s.ResetPasswordWithContext(context.Background(), userID, password)
+}
+
+// ResetPasswordWithContext 同 ResetPassword，ctx 用于控制请求的取消与超时
+func (s *Service) ResetPasswordWithContext(ctx context.Context, userID int, password string) (*types.UserInfo, error) {
+	if password == "" {
+		return nil, &sdkerr.ValidationError{Fields: []string{"password"}, Msg: "password is required"}
+	}
+	return s.OperationWithContext(ctx, &types.UserOperationRequest{UserID: userID, Password: password})
+}
@@ -0,0 +1,160 @@
+package users
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xxyijixx/dootask-golang-sdk/internal/core"
+	ihttp "github.com/xxyijixx/dootask-golang-sdk/internal/http"
+	"github.com/xxyijixx/dootask-golang-sdk/sdkerr"
+	"github.com/xxyijixx/dootask-golang-sdk/types"
+)
+
+// ==================== 部门管理 ====================
+
+// DepartmentList 08. 获取部门列表
+// 返回全部部门的平铺列表，可用 types.BuildDepartmentTree 组织为树
+func (s *Service) DepartmentList() ([]types.Department, error) {
+	return s.DepartmentListWithContext(context.Background())
+}
+
+// DepartmentListWithContext 同 DepartmentList，ctx 用于控制请求的取消与超时
+func (s *Service) DepartmentListWithContext(ctx context.Context) ([]types.Department, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/users/department/list", Idempotent: true})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result []types.Department
+	err = ihttp.ParseAPIResponse(resp, &result)
+
+	if err != nil {
+		return nil, fmt.Errorf("API error: %w", err)
+	}
+
+	return result, nil
+}
+
+// DepartmentTree 获取部门树
+// 在 DepartmentList 的基础上按上级部门组织，返回顶级部门，可用 types.WalkDepartments 遍历
+func (s *Service) DepartmentTree() ([]*types.Department, error) {
+	return s.DepartmentTreeWithContext(context.Background())
+}
+
+// DepartmentTreeWithContext 同 DepartmentTree，ctx 用于控制请求的取消与超时
+func (s *Service) DepartmentTreeWithContext(ctx context.Context) ([]*types.Department, error) {
+	list, err := s.DepartmentListWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return types.BuildDepartmentTree(list), nil
+}
+
+// DepartmentCreate 09. 新建部门（管理员）
+// 默认同时新建部门群聊，负责人为群主
+func (s *Service) DepartmentCreate(req *types.UserDepartmentAddRequest) (*types.Department, error) {
+	return s.DepartmentCreateWithContext(context.Background(), req)
+}
+
+// DepartmentCreateWithContext 同 DepartmentCreate，ctx 用于控制请求的取消与超时
+func (s *Service) DepartmentCreateWithContext(ctx context.Context, req *types.UserDepartmentAddRequest) (*types.Department, error) {
+	if req == nil || req.Name == "" {
+		return nil, &sdkerr.ValidationError{Fields: []string{"name"}, Msg: "department name is required"}
+	}
+	if req.ID != 0 {
+		return nil, &sdkerr.ValidationError{Fields: []string{"id"}, Msg: "use DepartmentUpdate to modify an existing department"}
+	}
+	return s.saveDepartment(ctx, req)
+}
+
+// DepartmentUpdate 09. 修改部门（管理员）
+// req.ID 为要修改的部门ID
+func (s *Service) DepartmentUpdate(req *types.UserDepartmentAddRequest) (*types.Department, error) {
+	return s.DepartmentUpdateWithContext(context.Background(), req)
+}
+
+// DepartmentUpdateWithContext 同 DepartmentUpdate，ctx 用于控制请求的取消与超时
+func (s *Service) DepartmentUpdateWithContext(ctx context.Context, req *types.UserDepartmentAddRequest) (*types.Department, error) {
+	if req == nil || req.ID == 0 {
+		return nil, &sdkerr.ValidationError{Fields: []string{"id"}, Msg: "department id is required"}
+	}
+	if req.Name == "" {
+		return nil, &sdkerr.ValidationError{Fields: []string{"name"}, Msg: "department name is required"}
+	}
+	return s.saveDepartment(ctx, req)
+}
+
+// saveDepartment 新建或修改部门
+func (s *Service) saveDepartment(ctx context.Context, req *types.UserDepartmentAddRequest) (*types.Department, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/users/department/add", Params: req})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result types.Department
+	err = ihttp.ParseAPIResponse(resp, &result)
+
+	if err != nil {
+		return nil, adminError(err)
+	}
+
+	return &result, nil
+}
+
+// DepartmentDelete 10. 删除部门（管理员）
+// 含有子部门的部门不能删除，成员会被移出该部门
+func (s *Service) DepartmentDelete(id int) error {
+	return s.DepartmentDeleteWithContext(context.Background(), id)
+}
+
+// DepartmentDeleteWithContext 同 DepartmentDelete，ctx 用于控制请求的取消与超时
+func (s *Service) DepartmentDeleteWithContext(ctx context.Context, id int) error {
+	req := types.UserDepartmentDelRequest{ID: id}
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/users/department/del", Params: req})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	err = ihttp.ParseAPIResponse[any](resp, nil)
+
+	if err != nil {
+		return adminError(err)
+	}
+
+	return nil
+}
+
+// DepartmentMembers 获取部门成员
+// 返回直属该部门的会员（含已禁用的会员，最多100个），不含子部门成员
+func (s *Service) DepartmentMembers(departmentID int) ([]types.UserBasic, error) {
+	return s.DepartmentMembersWithContext(context.Background(), departmentID)
+}
+
+// DepartmentMembersWithContext 同 DepartmentMembers，ctx 用于控制请求的取消与超时
+func (s *Service) DepartmentMembersWithContext(ctx context.Context, departmentID int) ([]types.UserBasic, error) {
+	return s.SearchWithContext(ctx, &types.UserSearchRequest{
+		Department: departmentID,
+		Disable:    2,
+		Take:       100,
+	})
+}
+
+// SetDepartments 11. 设置会员所属部门（管理员）
+// 以 departmentIDs 替换会员当前所属的全部部门；departmentIDs 不能为空，
+// 空列表无法在请求中表达，会返回 *ValidationError 而不是静默忽略
+func (s *Service) SetDepartments(userID int, departmentIDs []int) error {
+	return s.SetDepartmentsWithContext(context.Background(), userID, departmentIDs)
+}
+
+// SetDepartmentsWithContext 同 SetDepartments，ctx 用于控制请求的取消与超时
+func (s *Service) SetDepartmentsWithContext(ctx context.Context, userID int, departmentIDs []int) error {
+	_, err := s.OperationWithContext(ctx, &types.UserOperationRequest{
+		UserID:     userID,
+		Type:       types.UserOpDepartment,
+		Department: departmentIDs,
+	})
+	return err
+}
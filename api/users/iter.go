@@ -0,0 +1,26 @@
+package users
+
+import (
+	"context"
+	"iter"
+
+	"github.com/xxyijixx/dootask-golang-sdk/internal/paginate"
+	"github.com/xxyijixx/dootask-golang-sdk/types"
+)
+
+// AllUsers 遍历会员列表（管理员）的所有页，从 req.Page 开始（默认第 1 页），出错时产出错误并结束
+func (s *Service) AllUsers(req *types.UserListsRequest) iter.Seq2[types.UserInfo, error] {
+	return s.AllUsersWithContext(context.Background(), req)
+}
+
+// AllUsersWithContext 同 AllUsers，ctx 用于控制请求的取消与超时
+func (s *Service) AllUsersWithContext(ctx context.Context, req *types.UserListsRequest) iter.Seq2[types.UserInfo, error] {
+	var r types.UserListsRequest
+	if req != nil {
+		r = *req
+	}
+	return paginate.Pages(ctx, r.Page, func(ctx context.Context, page int) (*types.Page[types.UserInfo], error) {
+		r.Page = page
+		return s.ListWithContext(ctx, &r)
+	})
+}
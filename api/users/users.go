@@ -0,0 +1,210 @@
+package users
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xxyijixx/dootask-golang-sdk/internal/core"
+	ihttp "github.com/xxyijixx/dootask-golang-sdk/internal/http"
+	"github.com/xxyijixx/dootask-golang-sdk/sdkerr"
+	"github.com/xxyijixx/dootask-golang-sdk/types"
+)
+
+// Service 用户服务
+type Service struct {
+	client core.HTTPDoer
+}
+
+// New 创建用户服务实例
+func New(client core.HTTPDoer) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+// ==================== 登录与认证 ====================
+
+// Login 01. 登录、注册
+// 返回的用户信息包含 Token，可用于 client.SetToken 或 client.WithToken；
+// 密码放在 POST 请求体中，不会出现在访问日志里
+func (s *Service) Login(req *types.UserLoginRequest) (*types.UserInfo, error) {
+	return s.LoginWithContext(context.Background(), req)
+}
+
+// LoginWithContext 同 Login，ctx 用于控制请求的取消与超时
+func (s *Service) LoginWithContext(ctx context.Context, req *types.UserLoginRequest) (*types.UserInfo, error) {
+	if req == nil || req.Email == "" || req.Password == "" {
+		return nil, &sdkerr.ValidationError{Fields: []string{"email", "password"}, Msg: "email and password are required"}
+	}
+
+	resp, err := s.client.Do(ctx, &core.Request{Method: "POST", Path: "/api/users/login", Params: req})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result types.UserInfo
+	err = ihttp.ParseAPIResponse(resp, &result)
+
+	if err != nil {
+		return nil, fmt.Errorf("API error: %w", err)
+	}
+
+	return &result, nil
+}
+
+// Logout 02. 退出登录
+// 使当前令牌失效
+func (s *Service) Logout() error {
+	return s.LogoutWithContext(context.Background())
+}
+
+// LogoutWithContext 同 Logout，ctx 用于控制请求的取消与超时
+func (s *Service) LogoutWithContext(ctx context.Context) error {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/users/logout"})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	err = ihttp.ParseAPIResponse[any](resp, nil)
+
+	if err != nil {
+		return fmt.Errorf("API error: %w", err)
+	}
+
+	return nil
+}
+
+// ==================== 个人资料 ====================
+
+// Info 03. 获取我的信息
+// 返回当前令牌对应用户的详细信息
+func (s *Service) Info() (*types.UserInfo, error) {
+	return s.InfoWithContext(context.Background())
+}
+
+// InfoWithContext 同 Info，ctx 用于控制请求的取消与超时
+func (s *Service) InfoWithContext(ctx context.Context) (*types.UserInfo, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/users/info", Idempotent: true})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result types.UserInfo
+	err = ihttp.ParseAPIResponse(resp, &result)
+
+	if err != nil {
+		return nil, fmt.Errorf("API error: %w", err)
+	}
+
+	return &result, nil
+}
+
+// Update 04. 修改自己的资料
+// 仅提交设置了的字段，返回修改后的用户信息
+func (s *Service) Update(req *types.UserEditRequest) (*types.UserInfo, error) {
+	return s.UpdateWithContext(context.Background(), req)
+}
+
+// UpdateWithContext 同 Update，ctx 用于控制请求的取消与超时
+func (s *Service) UpdateWithContext(ctx context.Context, req *types.UserEditRequest) (*types.UserInfo, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/users/editdata", Params: req})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result types.UserInfo
+	err = ihttp.ParseAPIResponse(resp, &result)
+
+	if err != nil {
+		return nil, fmt.Errorf("API error: %w", err)
+	}
+
+	return &result, nil
+}
+
+// ChangePassword 05. 修改自己的密码
+// 修改成功后服务器会签发新的令牌，返回的用户信息中包含新的 Token；密码放在 POST 请求体中
+func (s *Service) ChangePassword(req *types.UserPasswordRequest) (*types.UserInfo, error) {
+	return s.ChangePasswordWithContext(context.Background(), req)
+}
+
+// ChangePasswordWithContext 同 ChangePassword，ctx 用于控制请求的取消与超时
+func (s *Service) ChangePasswordWithContext(ctx context.Context, req *types.UserPasswordRequest) (*types.UserInfo, error) {
+	if req == nil || req.OldPass == "" || req.NewPass == "" {
+		return nil, &sdkerr.ValidationError{Fields: []string{"oldpass", "newpass"}, Msg: "old and new passwords are required"}
+	}
+
+	resp, err := s.client.Do(ctx, &core.Request{Method: "POST", Path: "/api/users/editpass", Params: req})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result types.UserInfo
+	err = ihttp.ParseAPIResponse(resp, &result)
+
+	if err != nil {
+		return nil, fmt.Errorf("API error: %w", err)
+	}
+
+	return &result, nil
+}
+
+// ==================== 会员查询 ====================
+
+// Search 06. 搜索会员列表
+// 按关键词、项目、会话等条件搜索会员，默认排除已禁用的会员和机器人
+func (s *Service) Search(req *types.UserSearchRequest) ([]types.UserBasic, error) {
+	return s.SearchWithContext(context.Background(), req)
+}
+
+// SearchWithContext 同 Search，ctx 用于控制请求的取消与超时
+func (s *Service) SearchWithContext(ctx context.Context, req *types.UserSearchRequest) ([]types.UserBasic, error) {
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/users/search", Params: req, Idempotent: true})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result []types.UserBasic
+	err = ihttp.ParseAPIResponse(resp, &result)
+
+	if err != nil {
+		return nil, fmt.Errorf("API error: %w", err)
+	}
+
+	return result, nil
+}
+
+// Basic 07. 获取指定会员基础信息
+// 批量获取会员的昵称、头像等基础信息，已删除的会员也会返回
+func (s *Service) Basic(userIDs []int) ([]types.UserBasic, error) {
+	return s.BasicWithContext(context.Background(), userIDs)
+}
+
+// BasicWithContext 同 Basic，ctx 用于控制请求的取消与超时
+func (s *Service) BasicWithContext(ctx context.Context, userIDs []int) ([]types.UserBasic, error) {
+	if len(userIDs) == 0 {
+		return []types.UserBasic{}, nil
+	}
+
+	req := types.UserBasicRequest{UserIDs: userIDs}
+	resp, err := s.client.Do(ctx, &core.Request{Method: "GET", Path: "/api/users/basic", Params: req, Idempotent: true})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result []types.UserBasic
+	err = ihttp.ParseAPIResponse(resp, &result)
+
+	if err != nil {
+		return nil, fmt.Errorf("API error: %w", err)
+	}
+
+	return result, nil
+}
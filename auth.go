@@ -0,0 +1,273 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xxyijixx/dootask-golang-sdk/sdkerr"
+	"github.com/xxyijixx/dootask-golang-sdk/types"
+)
+
+// TokenSource supplies the DooTask token for each request
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// InvalidatingTokenSource is a TokenSource that can drop a token the server
+// rejected, so the next Token call obtains a fresh one
+type InvalidatingTokenSource interface {
+	TokenSource
+	Invalidate(token string)
+}
+
+// TokenSourceFunc adapts a plain function to TokenSource
+type TokenSourceFunc func(ctx context.Context) (string, error)
+
+// Token implements TokenSource
+func (f TokenSourceFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// StaticTokenSource always returns token
+func StaticTokenSource(token string) TokenSource {
+	return TokenSourceFunc(func(context.Context) (string, error) {
+		return token, nil
+	})
+}
+
+// LoginTokenSource obtains a token by logging in with email and password.
+// The returned source caches the token until the server rejects it.
+func LoginTokenSource(client *Client, email, password string) InvalidatingTokenSource {
+	return CachedTokenSource(TokenSourceFunc(func(ctx context.Context) (string, error) {
+		return client.login(ctx, email, password)
+	}), 0)
+}
+
+// CachedTokenSource caches tokens from src for ttl (forever when ttl is 0)
+// and fetches a new one once the cached token expires or is invalidated
+func CachedTokenSource(src TokenSource, ttl time.Duration) InvalidatingTokenSource {
+	return &cachedTokenSource{src: src, ttl: ttl}
+}
+
+type cachedTokenSource struct {
+	src TokenSource
+	ttl time.Duration
+
+	mu      sync.Mutex
+	token   string
+	fetched time.Time
+}
+
+func (s *cachedTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && (s.ttl == 0 || time.Since(s.fetched) < s.ttl) {
+		return s.token, nil
+	}
+
+	token, err := s.src.Token(ctx)
+	if err != nil {
+		return "", err
+	}
+	s.token, s.fetched = token, time.Now()
+	return token, nil
+}
+
+func (s *cachedTokenSource) Invalidate(token string) {
+	s.mu.Lock()
+	if s.token == token {
+		s.token = ""
+	}
+	s.mu.Unlock()
+
+	if inv, ok := s.src.(InvalidatingTokenSource); ok {
+		inv.Invalidate(token)
+	}
+}
+
+// FileTokenSource persists tokens from src in the file at path so they survive
+// restarts. The file is read on every call and rewritten after a refresh.
+func FileTokenSource(path string, src TokenSource) InvalidatingTokenSource {
+	return &fileTokenSource{path: path, src: src}
+}
+
+type fileTokenSource struct {
+	path string
+	src  TokenSource
+	mu   sync.Mutex
+}
+
+func (s *fileTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if b, err := os.ReadFile(s.path); err == nil {
+		if token := strings.TrimSpace(string(b)); token != "" {
+			return token, nil
+		}
+	}
+
+	token, err := s.src.Token(ctx)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(s.path, []byte(token), 0o600); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func (s *fileTokenSource) Invalidate(token string) {
+	s.mu.Lock()
+	if b, err := os.ReadFile(s.path); err == nil && strings.TrimSpace(string(b)) == token {
+		os.Remove(s.path)
+	}
+	s.mu.Unlock()
+
+	if inv, ok := s.src.(InvalidatingTokenSource); ok {
+		inv.Invalidate(token)
+	}
+}
+
+type (
+	authCtxKey  struct{}
+	tokenCtxKey struct{}
+)
+
+// withoutAuth marks requests that must not carry or refresh a token, such as login
+func withoutAuth(ctx context.Context) context.Context {
+	return context.WithValue(ctx, authCtxKey{}, true)
+}
+
+func skipAuth(ctx context.Context) bool {
+	skip, _ := ctx.Value(authCtxKey{}).(bool)
+	return skip
+}
+
+// ContextWithToken overrides the token for calls made with ctx, taking
+// precedence over the client's token and token source
+func ContextWithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenCtxKey{}, token)
+}
+
+func tokenFromContext(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(tokenCtxKey{}).(string)
+	return token, ok
+}
+
+// SetTokenSource makes the client consult src for the token of every request.
+// It takes precedence over SetToken.
+func (c *Client) SetTokenSource(src TokenSource) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.TokenSource = src
+}
+
+func (c *Client) tokenSource() TokenSource {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.TokenSource
+}
+
+// token returns the token for a request: the per-call override, the token
+// source when set, otherwise the static token
+func (c *Client) token(ctx context.Context) (string, error) {
+	if token, ok := tokenFromContext(ctx); ok {
+		return token, nil
+	}
+
+	c.mu.RLock()
+	token, src := c.Token, c.TokenSource
+	c.mu.RUnlock()
+
+	if src == nil {
+		return token, nil
+	}
+	token, err := src.Token(ctx)
+	if err != nil {
+		return "", &sdkerr.AuthError{Err: err}
+	}
+	return token, nil
+}
+
+// authMiddleware sets the Token header unless the caller already did
+func (c *Client) authMiddleware() Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if skipAuth(req.Context()) || req.Header.Get("Token") != "" {
+				return next(req)
+			}
+			token, err := c.token(req.Context())
+			if err != nil {
+				return nil, err
+			}
+			if token != "" {
+				req.Header.Set("Token", token)
+			}
+			return next(req)
+		}
+	}
+}
+
+// doWithAuth runs the request and, when the server rejects the token and the
+// token source can refresh it, invalidates the token and retries once. Under
+// WithoutRetry, e.g. for bodies that cannot be replayed, the token is still
+// invalidated but the rejection is returned instead of resending.
+func (c *Client) doWithAuth(ctx context.Context, newReq func(ctx context.Context) (*http.Request, error)) (*http.Response, int, error) {
+	// Remember the last request sent: resp.Request is not set by every
+	// transport or by middlewares answering requests themselves
+	var sent *http.Request
+	resp, attempts, err := c.doWithRetry(ctx, func(ctx context.Context) (*http.Request, error) {
+		req, err := newReq(ctx)
+		sent = req
+		return req, err
+	})
+
+	src, ok := c.tokenSource().(InvalidatingTokenSource)
+	_, overridden := tokenFromContext(ctx)
+	if err != nil || !ok || overridden || skipAuth(ctx) || !isAuthFailure(ctx, resp) {
+		return resp, attempts, err
+	}
+
+	src.Invalidate(sent.Header.Get("Token"))
+	if noRetry, _ := ctx.Value(noRetryKey).(bool); noRetry {
+		return resp, attempts, nil
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	resp, more, err := c.doWithRetry(ctx, newReq)
+	return resp, attempts + more, err
+}
+
+func isAuthFailure(ctx context.Context, resp *http.Response) bool {
+	if resp.StatusCode == http.StatusUnauthorized {
+		return true
+	}
+	ret := peekRet(ctx, resp)
+	return ret != nil && *ret == sdkerr.RetUnauthorized
+}
+
+// login exchanges email and password for a token via /api/users/login
+func (c *Client) login(ctx context.Context, email, password string) (string, error) {
+	req := &types.UserLoginRequest{Type: "login", Email: email, Password: password}
+	user, err := c.Users.LoginWithContext(withoutAuth(ctx), req)
+	if err != nil {
+		return "", err
+	}
+	if user.Token == "" {
+		return "", errors.New("login response did not contain a token")
+	}
+	return user.Token, nil
+}
@@ -0,0 +1,300 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingSource hands out t1, t2, ... and counts the fetches
+type countingSource struct {
+	fetches     atomic.Int32
+	delay       time.Duration
+	fail        atomic.Int32 // number of fetches left to fail
+	invalidated []string
+	mu          sync.Mutex
+}
+
+func (s *countingSource) Token(ctx context.Context) (string, error) {
+	time.Sleep(s.delay)
+	if s.fail.Load() > 0 {
+		s.fail.Add(-1)
+		return "", errors.New("login failed")
+	}
+	return fmt.Sprintf("t%d", s.fetches.Add(1)), nil
+}
+
+func (s *countingSource) Invalidate(token string) {
+	s.mu.Lock()
+	s.invalidated = append(s.invalidated, token)
+	s.mu.Unlock()
+}
+
+func TestCachedTokenSource(t *testing.T) {
+	// Steps: "get tN" expects Token to return tN, "invalidate tN" drops tN,
+	// "expire" waits out the ttl and "fail" makes the next fetch fail
+	tests := []struct {
+		name        string
+		ttl         time.Duration
+		steps       []string
+		wantFetches int32
+	}{
+		{"cached forever", 0,
+			[]string{"get t1", "get t1", "get t1"}, 1},
+		{"invalidate refetches", 0,
+			[]string{"get t1", "invalidate t1", "get t2", "get t2"}, 2},
+		{"stale invalidate keeps the new token", 0,
+			[]string{"get t1", "invalidate t1", "get t2", "invalidate t1", "get t2"}, 2},
+		{"ttl expiry refetches", 20 * time.Millisecond,
+			[]string{"get t1", "get t1", "expire", "get t2"}, 2},
+		{"errors are not cached", 0,
+			[]string{"fail", "get error", "get t1", "get t1"}, 1},
+		{"failed refresh is retried", 0,
+			[]string{"get t1", "invalidate t1", "fail", "get error", "get t2"}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := &countingSource{}
+			cached := CachedTokenSource(src, tt.ttl)
+
+			for i, step := range tt.steps {
+				var arg string
+				if n, _ := fmt.Sscanf(step, "get %s", &arg); n == 1 {
+					token, err := cached.Token(context.Background())
+					if arg == "error" {
+						if err == nil {
+							t.Fatalf("step %d: Token = %q, want an error", i, token)
+						}
+						continue
+					}
+					if err != nil || token != arg {
+						t.Fatalf("step %d: Token = %q, %v, want %q", i, token, err, arg)
+					}
+				} else if n, _ := fmt.Sscanf(step, "invalidate %s", &arg); n == 1 {
+					cached.Invalidate(arg)
+				} else if step == "expire" {
+					time.Sleep(tt.ttl + 5*time.Millisecond)
+				} else if step == "fail" {
+					src.fail.Store(1)
+				}
+			}
+
+			if got := src.fetches.Load(); got != tt.wantFetches {
+				t.Errorf("fetches = %d, want %d", got, tt.wantFetches)
+			}
+		})
+	}
+}
+
+func TestCachedTokenSourceForwardsInvalidate(t *testing.T) {
+	src := &countingSource{}
+	cached := CachedTokenSource(src, 0)
+	cached.Token(context.Background())
+	cached.Invalidate("t1")
+	cached.Invalidate("stale")
+
+	if want := []string{"t1", "stale"}; fmt.Sprint(src.invalidated) != fmt.Sprint(want) {
+		t.Errorf("inner source invalidated %v, want %v", src.invalidated, want)
+	}
+}
+
+func TestCachedTokenSourceConcurrent(t *testing.T) {
+	src := &countingSource{delay: 10 * time.Millisecond}
+	cached := CachedTokenSource(src, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := cached.Token(context.Background())
+			if err != nil || token != "t1" {
+				t.Errorf("Token = %q, %v, want t1", token, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := src.fetches.Load(); got != 1 {
+		t.Errorf("fetches = %d, want concurrent callers to share one", got)
+	}
+
+	// Every caller drops the token it saw, then asks again: only the first
+	// invalidation counts and they all share the refreshed token
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cached.Invalidate("t1")
+			if token, err := cached.Token(context.Background()); err != nil || token == "t1" {
+				t.Errorf("Token after invalidate = %q, %v", token, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if token, _ := cached.Token(context.Background()); token != "t2" {
+		t.Errorf("Token after invalidate = %q, want t2", token)
+	}
+}
+
+func TestDoWithAuthRefreshesRejectedToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		reject func(w http.ResponseWriter)
+	}{
+		{"401 status", func(w http.ResponseWriter) { w.WriteHeader(http.StatusUnauthorized) }},
+		{"ret -1", func(w http.ResponseWriter) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"ret":-1,"msg":"请登录后继续...","data":{}}`))
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// Only tokens fetched after the first are valid
+				if r.Header.Get("Token") == "t1" {
+					tt.reject(w)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"ret":1,"msg":"","data":null}`))
+			}))
+			defer srv.Close()
+
+			src := &countingSource{}
+			client := NewClientWithConfig(srv.URL, DefaultConfig().WithRetryCount(0))
+			client.SetTokenSource(CachedTokenSource(src, 0))
+
+			var wg sync.WaitGroup
+			for i := 0; i < 20; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					resp, err := client.Do(context.Background(), &Request{Method: "GET", Path: "/api/users/info"})
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					defer resp.Body.Close()
+					if resp.StatusCode != http.StatusOK {
+						t.Errorf("status = %d, want 200 after the refresh", resp.StatusCode)
+					}
+				}()
+			}
+			wg.Wait()
+
+			if got := src.fetches.Load(); got != 2 {
+				t.Errorf("fetches = %d, want one refresh shared by every caller", got)
+			}
+		})
+	}
+}
+
+func TestDoWithAuthResponseWithoutRequest(t *testing.T) {
+	// answer replies like a stub transport: no Request on the response
+	answer := func(req *http.Request) (*http.Response, error) {
+		status, body := http.StatusOK, `{"ret":1,"msg":"","data":null}`
+		if req.Header.Get("Token") == "t1" {
+			status, body = http.StatusUnauthorized, ""
+		}
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}, nil
+	}
+
+	tests := []struct {
+		name string
+		cfg  *Config
+	}{
+		{"transport", DefaultConfig().WithTransport(RoundTripFunc(answer))},
+		{"middleware", DefaultConfig().WithMiddleware(func(RoundTripFunc) RoundTripFunc { return answer })},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := &countingSource{}
+			client := NewClientWithConfig("http://dootask.test", tt.cfg.WithRetryCount(0))
+			client.SetTokenSource(CachedTokenSource(src, 0))
+
+			if _, err := client.Users.Info(); err != nil {
+				t.Fatalf("Info = %v, want success after the refresh", err)
+			}
+			if got := src.fetches.Load(); got != 2 {
+				t.Errorf("fetches = %d, want 2", got)
+			}
+		})
+	}
+}
+
+func TestDoWithAuthWithoutRetry(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		body       func() Body
+		wantStatus int
+		wantRet    string
+	}{
+		{"non-replayable body 401", context.Background(),
+			func() Body { return ReaderBody("text/plain", io.MultiReader(strings.NewReader("data"))) },
+			http.StatusUnauthorized, ""},
+		{"non-replayable body ret -1", context.Background(),
+			func() Body { return ReaderBody("text/plain", io.MultiReader(strings.NewReader("data"))) },
+			http.StatusOK, `"ret":-1`},
+		{"WithoutRetry", WithoutRetry(context.Background()), nil, http.StatusUnauthorized, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hits.Add(1)
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case r.Header.Get("Token") != "t1":
+					w.Write([]byte(`{"ret":1,"msg":"","data":null}`))
+				case tt.wantRet != "":
+					w.Write([]byte(`{"ret":-1,"msg":"请登录后继续...","data":{}}`))
+				default:
+					w.WriteHeader(http.StatusUnauthorized)
+				}
+			}))
+			defer srv.Close()
+
+			src := &countingSource{}
+			cached := CachedTokenSource(src, 0)
+			client := NewClientWithConfig(srv.URL, DefaultConfig().WithRetryCount(0))
+			client.SetTokenSource(cached)
+
+			req := &Request{Method: "POST", Path: "/api/file/content/upload"}
+			if tt.body != nil {
+				req.Body = tt.body()
+			}
+			resp, err := client.Do(tt.ctx, req)
+			if err != nil {
+				t.Fatalf("Do = %v, want the rejected response", err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus || !strings.Contains(string(body), tt.wantRet) {
+				t.Errorf("response = %d %s, want %d with %q", resp.StatusCode, body, tt.wantStatus, tt.wantRet)
+			}
+			if got := hits.Load(); got != 1 {
+				t.Errorf("server hit %d times, want the request not resent", got)
+			}
+			if token, _ := cached.Token(context.Background()); token != "t2" {
+				t.Errorf("Token after rejection = %q, want the rejected token invalidated", token)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// DefaultBatchWorkers is the number of operations a batch runs concurrently
+// when BatchOptions.Workers is 0 and no global MaxInFlight limit is set
+const DefaultBatchWorkers = 8
+
+// ErrBatchAborted is reported for operations a fail-fast batch never started
+// because an earlier operation failed
+var ErrBatchAborted = errors.New("batch aborted")
+
+// BatchOptions controls Client.Batch and BatchMap
+type BatchOptions struct {
+	// Workers caps the operations running at once. When 0 it defaults to the
+	// smallest MaxInFlight of the client's global limits (empty Prefix), or
+	// DefaultBatchWorkers.
+	Workers int
+	// FailFast cancels the context of running operations after the first
+	// error and skips the rest with ErrBatchAborted. By default every
+	// operation runs and reports its own error.
+	FailFast bool
+}
+
+// BatchResult is the outcome of one BatchMap item
+type BatchResult[T any] struct {
+	Value T
+	Err   error
+}
+
+// Batch runs op for i in [0, n) on a bounded pool of workers and returns the
+// error of every operation by index, nil entries meaning success. Calls made
+// by op still pass through the client's limiters, retries and circuit
+// breaker; op should use the ctx it is given so cancelling ctx, or a failure
+// under FailFast, stops it. Operations not started when ctx is done report
+// ctx.Err().
+func (c *Client) Batch(ctx context.Context, n int, opts BatchOptions, op func(ctx context.Context, i int) error) []error {
+	if n <= 0 {
+		return []error{}
+	}
+	errs := make([]error, n)
+
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		aborted bool
+	)
+	// skipped is the error of an operation that never ran
+	skipped := func() error {
+		if err := parent.Err(); err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if aborted {
+			return ErrBatchAborted
+		}
+		return ctx.Err()
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(c.batchWorkers(opts.Workers), n) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if ctx.Err() != nil {
+					errs[i] = skipped()
+					continue
+				}
+				if errs[i] = op(ctx, i); errs[i] != nil && opts.FailFast {
+					mu.Lock()
+					aborted = true
+					mu.Unlock()
+					cancel()
+				}
+			}
+		}()
+	}
+
+	for i := range n {
+		select {
+		case indexes <- i:
+			continue
+		case <-ctx.Done():
+		}
+		for j := i; j < n; j++ {
+			errs[j] = skipped()
+		}
+		break
+	}
+	close(indexes)
+	wg.Wait()
+	return errs
+}
+
+// BatchMap calls fn for every item through c.Batch and returns the results
+// in the order of items
+func BatchMap[In, Out any](ctx context.Context, c *Client, items []In, opts BatchOptions, fn func(ctx context.Context, item In) (Out, error)) []BatchResult[Out] {
+	results := make([]BatchResult[Out], len(items))
+	errs := c.Batch(ctx, len(items), opts, func(ctx context.Context, i int) error {
+		var err error
+		results[i].Value, err = fn(ctx, items[i])
+		return err
+	})
+	for i, err := range errs {
+		results[i].Err = err
+	}
+	return results
+}
+
+// batchWorkers returns the worker count for a batch
+func (c *Client) batchWorkers(workers int) int {
+	if workers > 0 {
+		return workers
+	}
+	workers = DefaultBatchWorkers
+	if c.Config != nil {
+		for _, l := range c.Config.Limits {
+			if l.Prefix == "" && l.MaxInFlight > 0 {
+				workers = min(workers, l.MaxInFlight)
+			}
+		}
+	}
+	return workers
+}
@@ -0,0 +1,228 @@
+package sdk_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sdk "github.com/xxyijixx/dootask-golang-sdk"
+	"github.com/xxyijixx/dootask-golang-sdk/dootasktest"
+)
+
+var errBoom = errors.New("boom")
+
+func TestBatchErrors(t *testing.T) {
+	abort := sdk.ErrBatchAborted
+	tests := []struct {
+		name     string
+		n        int
+		failFast bool
+		fail     int // index of the failing operation, -1 for none
+		want     []error
+	}{
+		{"empty", 0, false, -1, []error{}},
+		{"negative", -3, false, -1, []error{}},
+		{"all succeed", 3, false, -1, []error{nil, nil, nil}},
+		{"failure reported by index", 4, false, 1, []error{nil, errBoom, nil, nil}},
+		{"fail fast skips the rest", 5, true, 2, []error{nil, nil, errBoom, abort, abort}},
+		{"fail fast on the last", 3, true, 2, []error{nil, nil, errBoom}},
+	}
+
+	client := sdk.NewClientWithConfig("http://dootask.test", sdk.DefaultConfig())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ran []int
+			// One worker runs the operations in order
+			errs := client.Batch(context.Background(), tt.n, sdk.BatchOptions{Workers: 1, FailFast: tt.failFast},
+				func(ctx context.Context, i int) error {
+					ran = append(ran, i)
+					if i == tt.fail {
+						return errBoom
+					}
+					return nil
+				})
+
+			if len(errs) != len(tt.want) {
+				t.Fatalf("got %d errors, want %d", len(errs), len(tt.want))
+			}
+			for i := range errs {
+				if !errors.Is(errs[i], tt.want[i]) || (tt.want[i] == nil) != (errs[i] == nil) {
+					t.Errorf("errs[%d] = %v, want %v", i, errs[i], tt.want[i])
+				}
+			}
+			for _, i := range ran {
+				if errors.Is(errs[i], sdk.ErrBatchAborted) {
+					t.Errorf("operation %d ran but reported ErrBatchAborted", i)
+				}
+			}
+		})
+	}
+}
+
+func TestBatchWorkers(t *testing.T) {
+	tests := []struct {
+		name    string
+		workers int
+		limits  []sdk.Limit
+		n       int
+		want    int32
+	}{
+		{"explicit workers", 3, nil, 20, 3},
+		{"explicit workers beat limits", 4, []sdk.Limit{{MaxInFlight: 2}}, 20, 4},
+		{"default", 0, nil, 20, sdk.DefaultBatchWorkers},
+		{"global MaxInFlight", 0, []sdk.Limit{{MaxInFlight: 2}}, 20, 2},
+		{"smallest global MaxInFlight", 0, []sdk.Limit{{MaxInFlight: 5}, {MaxInFlight: 3}}, 20, 3},
+		{"prefixed limits are ignored", 0, []sdk.Limit{{Prefix: "/api/dialog/", MaxInFlight: 1}}, 20, sdk.DefaultBatchWorkers},
+		{"no more workers than operations", 10, nil, 2, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := sdk.DefaultConfig()
+			for _, l := range tt.limits {
+				cfg.WithLimit(l)
+			}
+			client := sdk.NewClientWithConfig("http://dootask.test", cfg)
+
+			var running, peak atomic.Int32
+			full := make(chan struct{})
+			var once sync.Once
+			errs := client.Batch(context.Background(), tt.n, sdk.BatchOptions{Workers: tt.workers},
+				func(ctx context.Context, i int) error {
+					n := running.Add(1)
+					defer running.Add(-1)
+					for {
+						p := peak.Load()
+						if n <= p || peak.CompareAndSwap(p, n) {
+							break
+						}
+					}
+					// Hold every worker busy until the pool is full once
+					if n == tt.want {
+						once.Do(func() { close(full) })
+					}
+					select {
+					case <-full:
+					case <-time.After(2 * time.Second):
+						return errors.New("pool never filled")
+					}
+					return nil
+				})
+
+			for i, err := range errs {
+				if err != nil {
+					t.Fatalf("errs[%d] = %v", i, err)
+				}
+			}
+			if got := peak.Load(); got != tt.want {
+				t.Errorf("peak concurrency = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBatchFailFastCancelsRunning(t *testing.T) {
+	client := sdk.NewClientWithConfig("http://dootask.test", sdk.DefaultConfig())
+	started := make(chan struct{})
+	errs := client.Batch(context.Background(), 6, sdk.BatchOptions{Workers: 2, FailFast: true},
+		func(ctx context.Context, i int) error {
+			switch i {
+			case 0:
+				<-started
+				return errBoom
+			case 1:
+				close(started)
+				<-ctx.Done()
+				return ctx.Err()
+			}
+			return nil
+		})
+
+	if !errors.Is(errs[0], errBoom) {
+		t.Errorf("errs[0] = %v, want errBoom", errs[0])
+	}
+	if !errors.Is(errs[1], context.Canceled) {
+		t.Errorf("errs[1] = %v, want the running operation cancelled", errs[1])
+	}
+	for i := 2; i < len(errs); i++ {
+		if !errors.Is(errs[i], sdk.ErrBatchAborted) {
+			t.Errorf("errs[%d] = %v, want ErrBatchAborted", i, errs[i])
+		}
+	}
+}
+
+func TestBatchParentCancelled(t *testing.T) {
+	tests := []struct {
+		name     string
+		failFast bool
+	}{
+		{"default", false},
+		{"fail fast", true},
+	}
+
+	client := sdk.NewClientWithConfig("http://dootask.test", sdk.DefaultConfig())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			errs := client.Batch(ctx, 4, sdk.BatchOptions{Workers: 1, FailFast: tt.failFast},
+				func(ctx context.Context, i int) error {
+					if i == 1 {
+						cancel()
+					}
+					return nil
+				})
+
+			for i, err := range errs {
+				var want error
+				if i > 1 {
+					want = context.Canceled
+				}
+				if err != want {
+					t.Errorf("errs[%d] = %v, want %v", i, err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBatchMap(t *testing.T) {
+	srv := dootasktest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	var ids []int
+	for i := 0; i < 12; i++ {
+		u := srv.AddUser(fmt.Sprintf("u%d@example.com", i), fmt.Sprintf("user %d", i), "secret")
+		ids = append(ids, u.ID)
+	}
+	ids = append(ids, 0)
+
+	results := sdk.BatchMap(context.Background(), client, ids, sdk.BatchOptions{Workers: 4},
+		func(ctx context.Context, id int) (string, error) {
+			users, err := client.Users.BasicWithContext(ctx, []int{id})
+			if err != nil {
+				return "", err
+			}
+			if len(users) == 0 {
+				return "", fmt.Errorf("user %d not found", id)
+			}
+			return users[0].Nickname, nil
+		})
+
+	if len(results) != len(ids) {
+		t.Fatalf("got %d results, want %d", len(results), len(ids))
+	}
+	for i, r := range results[:len(results)-1] {
+		if want := fmt.Sprintf("user %d", i); r.Err != nil || r.Value != want {
+			t.Errorf("results[%d] = %q, %v, want %q", i, r.Value, r.Err, want)
+		}
+	}
+	if last := results[len(results)-1]; last.Err == nil {
+		t.Errorf("results for a missing user = %q, want an error", last.Value)
+	}
+}
@@ -0,0 +1,209 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/xxyijixx/dootask-golang-sdk/sdkerr"
+)
+
+// CircuitState is the state of the client's circuit breaker
+type CircuitState int
+
+const (
+	// CircuitClosed lets every request through
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects every request with *CircuitOpenError
+	CircuitOpen
+	// CircuitHalfOpen lets a limited number of probe requests through
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// CircuitBreaker configures the client's circuit breaker. After
+// FailureThreshold consecutive failed attempts the circuit opens and requests
+// fail fast with *CircuitOpenError. Once CoolDown has passed, HalfOpenRequests
+// probes are let through: the circuit closes when they all succeed and opens
+// again on the first failure.
+type CircuitBreaker struct {
+	FailureThreshold int           // consecutive failures that open the circuit, defaults to 5
+	CoolDown         time.Duration // time the circuit stays open, defaults to 30s
+	HalfOpenRequests int           // probes needed to close the circuit, defaults to 1
+
+	// IsFailure classifies an attempt, by default transport errors and 5xx
+	// responses count as failures. Attempts cancelled by the caller never count.
+	IsFailure func(resp *http.Response, err error) bool
+
+	// OnStateChange is called after every state transition
+	OnStateChange func(from, to CircuitState)
+}
+
+// breaker holds the circuit state shared by a client and its clones
+type breaker struct {
+	settings CircuitBreaker
+
+	mu         sync.Mutex
+	state      CircuitState
+	generation int // bumped on every transition to drop results of older attempts
+	failures   int
+	openedAt   time.Time
+	probes     int               // probes in flight while half-open
+	successes  int               // successful probes while half-open
+	changes    [][2]CircuitState // transitions not yet reported to OnStateChange
+}
+
+func newBreaker(settings CircuitBreaker) *breaker {
+	if settings.FailureThreshold <= 0 {
+		settings.FailureThreshold = 5
+	}
+	if settings.CoolDown <= 0 {
+		settings.CoolDown = 30 * time.Second
+	}
+	if settings.HalfOpenRequests <= 0 {
+		settings.HalfOpenRequests = 1
+	}
+	if settings.IsFailure == nil {
+		settings.IsFailure = func(resp *http.Response, err error) bool {
+			return err != nil || resp.StatusCode >= 500
+		}
+	}
+	return &breaker{settings: settings}
+}
+
+// current returns the state, moving from open to half-open once the
+// cool-down has passed
+func (b *breaker) current() CircuitState {
+	b.mu.Lock()
+	defer b.unlock()
+	b.refresh(time.Now())
+	return b.state
+}
+
+// refresh must be called with b.mu held
+func (b *breaker) refresh(now time.Time) {
+	if b.state == CircuitOpen && now.Sub(b.openedAt) >= b.settings.CoolDown {
+		b.transition(CircuitHalfOpen, now)
+	}
+}
+
+// transition must be called with b.mu held
+func (b *breaker) transition(to CircuitState, now time.Time) {
+	from := b.state
+	b.state = to
+	b.generation++
+	b.failures, b.probes, b.successes = 0, 0, 0
+	if to == CircuitOpen {
+		b.openedAt = now
+	}
+	if b.settings.OnStateChange != nil && from != to {
+		b.changes = append(b.changes, [2]CircuitState{from, to})
+	}
+}
+
+// unlock releases b.mu and then reports pending transitions, so the hook may
+// inspect the breaker
+func (b *breaker) unlock() {
+	changes := b.changes
+	b.changes = nil
+	b.mu.Unlock()
+	for _, change := range changes {
+		b.settings.OnStateChange(change[0], change[1])
+	}
+}
+
+// allow admits an attempt and returns the func reporting its outcome
+func (b *breaker) allow() (func(failed, ignored bool), error) {
+	b.mu.Lock()
+	defer b.unlock()
+
+	now := time.Now()
+	b.refresh(now)
+
+	switch b.state {
+	case CircuitOpen:
+		return nil, &sdkerr.CircuitOpenError{RetryAfter: b.settings.CoolDown - now.Sub(b.openedAt)}
+	case CircuitHalfOpen:
+		if b.probes+b.successes >= b.settings.HalfOpenRequests {
+			return nil, &sdkerr.CircuitOpenError{}
+		}
+		b.probes++
+	}
+
+	generation := b.generation
+	return func(failed, ignored bool) {
+		b.mu.Lock()
+		defer b.unlock()
+		if generation != b.generation {
+			return
+		}
+		b.record(failed, ignored, time.Now())
+	}, nil
+}
+
+// record must be called with b.mu held
+func (b *breaker) record(failed, ignored bool, now time.Time) {
+	switch b.state {
+	case CircuitClosed:
+		switch {
+		case ignored:
+		case failed:
+			b.failures++
+			if b.failures >= b.settings.FailureThreshold {
+				b.transition(CircuitOpen, now)
+			}
+		default:
+			b.failures = 0
+		}
+	case CircuitHalfOpen:
+		b.probes--
+		switch {
+		case ignored:
+		case failed:
+			b.transition(CircuitOpen, now)
+		default:
+			b.successes++
+			if b.successes >= b.settings.HalfOpenRequests {
+				b.transition(CircuitClosed, now)
+			}
+		}
+	}
+}
+
+func (b *breaker) middleware(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		done, err := b.allow()
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := next(req)
+		ignored := err != nil && (req.Context().Err() != nil ||
+			errors.Is(err, context.Canceled) || errors.Is(err, sdkerr.ErrRateLimited) ||
+			errors.Is(err, sdkerr.ErrUnauthorized))
+		done(!ignored && b.settings.IsFailure(resp, err), ignored)
+		return resp, err
+	}
+}
+
+// CircuitState reports the state of the circuit breaker for health checks,
+// always CircuitClosed when Config.CircuitBreaker is not set
+func (c *Client) CircuitState() CircuitState {
+	if c.breaker == nil {
+		return CircuitClosed
+	}
+	return c.breaker.current()
+}
@@ -0,0 +1,272 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/xxyijixx/dootask-golang-sdk/sdkerr"
+)
+
+const testCoolDown = 20 * time.Millisecond
+
+func TestBreakerTransitions(t *testing.T) {
+	// Steps: "ok", "fail" and "ignore" run an attempt with that outcome,
+	// "reject" expects the attempt to be refused and "cool" waits out CoolDown
+	tests := []struct {
+		name        string
+		threshold   int
+		probes      int
+		steps       []string
+		want        CircuitState
+		wantChanges []string
+	}{
+		{"stays closed below threshold", 3, 1,
+			[]string{"fail", "fail", "ok", "fail", "fail"},
+			CircuitClosed, nil},
+		{"opens at threshold", 2, 1,
+			[]string{"fail", "fail", "reject"},
+			CircuitOpen, []string{"closed->open"}},
+		{"ignored attempts do not count", 2, 1,
+			[]string{"fail", "ignore", "ignore", "ok", "fail", "ignore"},
+			CircuitClosed, nil},
+		{"half-open after cool-down", 1, 1,
+			[]string{"fail", "reject", "cool"},
+			CircuitHalfOpen, []string{"closed->open", "open->half-open"}},
+		{"probe success closes", 1, 1,
+			[]string{"fail", "cool", "ok", "ok"},
+			CircuitClosed, []string{"closed->open", "open->half-open", "half-open->closed"}},
+		{"probe failure reopens", 1, 1,
+			[]string{"fail", "cool", "fail", "reject"},
+			CircuitOpen, []string{"closed->open", "open->half-open", "half-open->open"}},
+		{"needs every probe", 1, 3,
+			[]string{"fail", "cool", "ok", "ok"},
+			CircuitHalfOpen, []string{"closed->open", "open->half-open"}},
+		{"closes after all probes", 1, 3,
+			[]string{"fail", "cool", "ok", "ok", "ok"},
+			CircuitClosed, []string{"closed->open", "open->half-open", "half-open->closed"}},
+		{"ignored probe frees its slot", 1, 1,
+			[]string{"fail", "cool", "ignore", "ok"},
+			CircuitClosed, []string{"closed->open", "open->half-open", "half-open->closed"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var changes []string
+			b := newBreaker(CircuitBreaker{
+				FailureThreshold: tt.threshold,
+				CoolDown:         testCoolDown,
+				HalfOpenRequests: tt.probes,
+				OnStateChange: func(from, to CircuitState) {
+					changes = append(changes, from.String()+"->"+to.String())
+				},
+			})
+
+			for i, step := range tt.steps {
+				if step == "cool" {
+					time.Sleep(testCoolDown + 5*time.Millisecond)
+					continue
+				}
+				done, err := b.allow()
+				if step == "reject" {
+					if !errors.Is(err, sdkerr.ErrCircuitOpen) {
+						t.Fatalf("step %d: allow = %v, want ErrCircuitOpen", i, err)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("step %d (%s): allow = %v", i, step, err)
+				}
+				done(step == "fail", step == "ignore")
+			}
+
+			if got := b.current(); got != tt.want {
+				t.Errorf("state = %s, want %s", got, tt.want)
+			}
+			if !slices.Equal(changes, tt.wantChanges) {
+				t.Errorf("changes = %v, want %v", changes, tt.wantChanges)
+			}
+		})
+	}
+}
+
+func TestBreakerLimitsProbesInFlight(t *testing.T) {
+	b := newBreaker(CircuitBreaker{FailureThreshold: 1, CoolDown: testCoolDown, HalfOpenRequests: 2})
+	done, _ := b.allow()
+	done(true, false)
+	time.Sleep(testCoolDown + 5*time.Millisecond)
+
+	first, err := b.allow()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := b.allow()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.allow(); !errors.Is(err, sdkerr.ErrCircuitOpen) {
+		t.Fatalf("third probe = %v, want ErrCircuitOpen", err)
+	}
+
+	first(false, false)
+	if _, err := b.allow(); !errors.Is(err, sdkerr.ErrCircuitOpen) {
+		t.Fatalf("probe after one success = %v, want ErrCircuitOpen", err)
+	}
+	second(false, false)
+	if got := b.current(); got != CircuitClosed {
+		t.Errorf("state = %s, want closed", got)
+	}
+}
+
+func TestBreakerDropsStaleResults(t *testing.T) {
+	tests := []struct {
+		name   string
+		failed bool
+	}{
+		{"stale failure", true},
+		{"stale success", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newBreaker(CircuitBreaker{FailureThreshold: 1, CoolDown: testCoolDown, HalfOpenRequests: 2})
+			stale, err := b.allow()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			done, _ := b.allow()
+			done(true, false)
+			time.Sleep(testCoolDown + 5*time.Millisecond)
+			if got := b.current(); got != CircuitHalfOpen {
+				t.Fatalf("state = %s, want half-open", got)
+			}
+
+			generation := b.generation
+			stale(tt.failed, false)
+			if got := b.current(); got != CircuitHalfOpen {
+				t.Errorf("state after stale result = %s, want half-open", got)
+			}
+			if b.generation != generation || b.probes != 0 || b.successes != 0 {
+				t.Errorf("stale result changed the breaker: generation %d->%d, probes %d, successes %d",
+					generation, b.generation, b.probes, b.successes)
+			}
+		})
+	}
+}
+
+func TestBreakerOpenErrorRetryAfter(t *testing.T) {
+	b := newBreaker(CircuitBreaker{FailureThreshold: 1, CoolDown: time.Minute})
+	done, _ := b.allow()
+	done(true, false)
+
+	_, err := b.allow()
+	var open *sdkerr.CircuitOpenError
+	if !errors.As(err, &open) {
+		t.Fatalf("allow = %v, want *CircuitOpenError", err)
+	}
+	if open.RetryAfter <= 0 || open.RetryAfter > time.Minute {
+		t.Errorf("RetryAfter = %v, want within (0, 1m]", open.RetryAfter)
+	}
+}
+
+func TestBreakerMiddleware(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		cancel    bool
+		wantState CircuitState
+	}{
+		{"server errors open", http.StatusBadGateway, false, CircuitOpen},
+		{"client errors do not count", http.StatusNotFound, false, CircuitClosed},
+		{"success keeps closed", http.StatusOK, false, CircuitClosed},
+		{"cancelled calls do not count", http.StatusBadGateway, true, CircuitClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(`{"ret":1,"msg":"","data":null}`))
+			}))
+			defer srv.Close()
+
+			cfg := DefaultConfig().WithRetryCount(0).
+				WithCircuitBreaker(CircuitBreaker{FailureThreshold: 2, CoolDown: time.Minute})
+			client := NewClientWithConfig(srv.URL, cfg)
+
+			for i := 0; i < 2; i++ {
+				ctx, cancel := context.WithCancel(context.Background())
+				if tt.cancel {
+					cancel()
+				}
+				resp, err := client.Do(ctx, &Request{Method: "GET", Path: "/api/users/info"})
+				if err == nil {
+					resp.Body.Close()
+				}
+				cancel()
+			}
+
+			if got := client.CircuitState(); got != tt.wantState {
+				t.Errorf("CircuitState = %s, want %s", got, tt.wantState)
+			}
+			if tt.wantState != CircuitOpen {
+				return
+			}
+			_, err := client.Do(context.Background(), &Request{Method: "GET", Path: "/api/users/info"})
+			var open *sdkerr.CircuitOpenError
+			if !errors.As(err, &open) {
+				t.Fatalf("call on open circuit = %v, want *CircuitOpenError", err)
+			}
+			var transport *sdkerr.TransportError
+			if errors.As(err, &transport) {
+				t.Errorf("call on open circuit = %v, want it not wrapped in *TransportError", err)
+			}
+		})
+	}
+}
+
+func TestBreakerConcurrent(t *testing.T) {
+	var b *breaker
+	b = newBreaker(CircuitBreaker{
+		FailureThreshold: 3,
+		CoolDown:         time.Millisecond,
+		HalfOpenRequests: 2,
+		// The hook runs without the lock held, so it may inspect the breaker
+		OnStateChange: func(from, to CircuitState) { b.current() },
+	})
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				done, err := b.allow()
+				if err != nil {
+					if !errors.Is(err, sdkerr.ErrCircuitOpen) {
+						t.Errorf("allow = %v", err)
+					}
+					continue
+				}
+				done((g+i)%3 == 0, i%7 == 0)
+			}
+		}()
+	}
+	wg.Wait()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.probes < 0 || b.probes > b.settings.HalfOpenRequests {
+		t.Errorf("probes = %d after all attempts finished", b.probes)
+	}
+	if b.state == CircuitHalfOpen && b.probes != 0 {
+		t.Errorf("%d probes still counted in flight", b.probes)
+	}
+}
@@ -2,29 +2,50 @@ package sdk
 
 import (
 	"bytes"
-	"crypto/tls"
+	"context"
 	"encoding/json"
+	"io"
+	"maps"
 	"net/http"
+	"sync"
 
+	"github.com/xxyijixx/dootask-golang-sdk/api/approve"
 	"github.com/xxyijixx/dootask-golang-sdk/api/dialog"
 	"github.com/xxyijixx/dootask-golang-sdk/api/file"
 	"github.com/xxyijixx/dootask-golang-sdk/api/project"
 	"github.com/xxyijixx/dootask-golang-sdk/api/report"
+	"github.com/xxyijixx/dootask-golang-sdk/api/system"
+	"github.com/xxyijixx/dootask-golang-sdk/api/users"
+	"github.com/xxyijixx/dootask-golang-sdk/internal/core"
 	ihttp "github.com/xxyijixx/dootask-golang-sdk/internal/http"
 )
 
-// Client represents the main API client
+// Client represents the main API client. It is safe for concurrent use as
+// long as Token and TokenSource are changed through SetToken and
+// SetTokenSource; use WithToken to act on behalf of another user.
 type Client struct {
 	BaseURL    string
 	HTTPClient *http.Client
 	Token      string
 	Config     *Config
 
+	// TokenSource, when set, supplies the token of every request instead of Token
+	TokenSource TokenSource
+
 	// Service modules
 	File    *file.Service
 	Dialog  *dialog.Service
 	Project *project.Service
 	Report  *report.Service
+	Users   *users.Service
+	System  *system.Service
+	Approve *approve.Service
+
+	mu      sync.RWMutex      // guards Token and TokenSource
+	headers map[string]string // extra headers added by WithHeaders
+	limits  Middleware        // shared limiter state built from Config.Limits
+	breaker *breaker          // shared circuit state built from Config.CircuitBreaker
+	initErr error             // returned by every request when Config is unusable
 }
 
 // NewClient creates a new API client
@@ -32,85 +53,230 @@ func NewClient(baseURL string) *Client {
 	return NewClientWithConfig(baseURL, DefaultConfig())
 }
 
-// NewClientWithConfig creates a new API client with custom configuration
+// NewClientFromConfig creates a new API client whose base URL and token come
+// from config, e.g. one returned by LoadConfig or ConfigFromEnv
+func NewClientFromConfig(config *Config) *Client {
+	return NewClientWithConfig("", config)
+}
+
+// NewClientWithConfig creates a new API client with custom configuration.
+// An empty baseURL falls back to config.BaseURL. When the transport settings
+// are invalid (see Config.Validate), every request returns a *ValidationError.
 func NewClientWithConfig(baseURL string, config *Config) *Client {
-	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: config.Insecure,
-		},
+	if baseURL == "" {
+		baseURL = config.BaseURL
+	}
+
+	httpClient, err := newHTTPClient(config)
+	if err != nil {
+		httpClient = &http.Client{Timeout: config.Timeout}
 	}
 
 	client := &Client{
-		BaseURL: baseURL,
-		HTTPClient: &http.Client{
-			Timeout:   config.Timeout,
-			Transport: transport,
-		},
-		Config: config,
+		BaseURL:    baseURL,
+		HTTPClient: httpClient,
+		Token:      config.Token,
+		Config:     config,
+	}
+	if err != nil {
+		client.initErr = configError(err)
+	}
+
+	if len(config.Limits) > 0 {
+		client.limits = LimitMiddleware(config.Limits...)
+	}
+	if config.CircuitBreaker != nil {
+		client.breaker = newBreaker(*config.CircuitBreaker)
 	}
 
-	// Initialize service modules
-	client.File = file.New(client)
-	client.Dialog = dialog.New(client)
-	client.Project = project.New(client)
-	client.Report = report.New(client)
+	client.initServices()
 
 	return client
 }
 
+// initServices binds the service modules to c
+func (c *Client) initServices() {
+	c.File = file.New(c)
+	c.Dialog = dialog.New(c)
+	c.Project = project.New(c)
+	c.Report = report.New(c)
+	c.Users = users.New(c)
+	c.System = system.New(c)
+	c.Approve = approve.New(c)
+}
+
 // SetToken sets the authentication token
 func (c *Client) SetToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.Token = token
 }
 
+// WithToken returns a copy of the client that authenticates with token.
+// The copy shares the transport, configuration, limiters and circuit breaker with c, so it is
+// cheap enough to create per request, e.g. in a multi-tenant gateway.
+func (c *Client) WithToken(token string) *Client {
+	clone := c.clone()
+	clone.Token = token
+	clone.TokenSource = nil
+	return clone
+}
+
+// WithHeaders returns a copy of the client that adds headers to every request,
+// on top of any headers added by earlier WithHeaders calls
+func (c *Client) WithHeaders(headers map[string]string) *Client {
+	clone := c.clone()
+	if clone.headers == nil {
+		clone.headers = make(map[string]string, len(headers))
+	}
+	maps.Copy(clone.headers, headers)
+	return clone
+}
+
+// clone returns a shallow copy of c with its own services and headers
+func (c *Client) clone() *Client {
+	c.mu.RLock()
+	token, src := c.Token, c.TokenSource
+	c.mu.RUnlock()
+
+	clone := &Client{
+		BaseURL:     c.BaseURL,
+		HTTPClient:  c.HTTPClient,
+		Token:       token,
+		Config:      c.Config,
+		TokenSource: src,
+		headers:     maps.Clone(c.headers),
+		limits:      c.limits,
+		breaker:     c.breaker,
+		initErr:     c.initErr,
+	}
+	clone.initServices()
+	return clone
+}
+
 // DoRequest performs an HTTP request
 func (c *Client) DoRequest(method, endpoint string, body interface{}) (*http.Response, error) {
-	return c.DoRequestWithHeaders(method, endpoint, body, nil)
+	return c.DoRequestWithHeadersContext(context.Background(), method, endpoint, body, nil)
+}
+
+// DoRequestWithContext performs an HTTP request bound to ctx
+func (c *Client) DoRequestWithContext(ctx context.Context, method, endpoint string, body interface{}) (*http.Response, error) {
+	return c.DoRequestWithHeadersContext(ctx, method, endpoint, body, nil)
 }
 
 // DoRequestWithHeaders performs an HTTP request with additional headers
 func (c *Client) DoRequestWithHeaders(method, endpoint string, body interface{}, headers map[string]string) (*http.Response, error) {
-	var buf bytes.Buffer
-	if body != nil {
-		if err := json.NewEncoder(&buf).Encode(body); err != nil {
-			return nil, err
+	return c.DoRequestWithHeadersContext(context.Background(), method, endpoint, body, headers)
+}
+
+// DoRequestWithHeadersContext performs an HTTP request with additional headers bound to ctx.
+// Cancelling ctx or exceeding its deadline aborts the in-flight request.
+func (c *Client) DoRequestWithHeadersContext(ctx context.Context, method, endpoint string, body interface{}, headers map[string]string) (*http.Response, error) {
+	req := &Request{Method: method, Path: endpoint, Params: body}
+	if len(headers) > 0 {
+		req.Header = make(http.Header, len(headers))
+		for key, value := range headers {
+			req.Header.Set(key, value)
 		}
 	}
+	return c.Do(ctx, req)
+}
 
-	url := c.BaseURL + endpoint
-	if c.Config.Debug {
-		ihttp.LogRequest(method, url, body)
+// Do performs the API call described by r and returns the raw response,
+// which the caller must close. It implements HTTPDoer, the interface the
+// service modules are built on.
+func (c *Client) Do(ctx context.Context, r *Request) (*http.Response, error) {
+	if c.initErr != nil {
+		return nil, c.initErr
 	}
 
-	req, err := http.NewRequest(method, url, &buf)
-	if err != nil {
-		return nil, err
+	url := ihttp.AppendQuery(c.BaseURL+r.Path, r.Query)
+	var (
+		open        func() (io.Reader, error)
+		contentType string
+	)
+	if r.Body != nil {
+		if r.Params != nil {
+			values, err := ihttp.EncodeValues(r.Params)
+			if err != nil {
+				return nil, err
+			}
+			url = ihttp.AppendQuery(url, values)
+		}
+		open, contentType = r.Body.Open, r.Body.ContentType()
+		if body, ok := r.Body.(core.ReplayableBody); !ok || !body.Replayable() {
+			ctx = WithoutRetry(ctx)
+		}
+	} else {
+		var (
+			payload []byte
+			err     error
+		)
+		payload, contentType, url, err = c.encodeBody(r.Method, url, r.Params)
+		if err != nil {
+			return nil, err
+		}
+		open = func() (io.Reader, error) { return bytes.NewReader(payload), nil }
 	}
-
-	// Set default headers
-	req.Header.Set("Content-Type", "application/json")
-	if c.Config.UserAgent != "" {
-		req.Header.Set("User-Agent", c.Config.UserAgent)
+	if r.Stream {
+		ctx = withStream(ctx)
 	}
-
-	// Set authentication token if available
-	if c.Token != "" {
-		req.Header.Set("Token", c.Token)
+	if r.Idempotent {
+		ctx = WithIdempotent(ctx)
 	}
+	ctx = withEndpoint(ctx, r.Path)
+
+	call := func(ctx context.Context) (*http.Response, int, error) {
+		return c.doWithAuth(ctx, func(ctx context.Context) (*http.Request, error) {
+			body, err := open()
+			if err != nil {
+				return nil, err
+			}
+			req, err := http.NewRequestWithContext(ctx, r.Method, url, body)
+			if err != nil {
+				return nil, err
+			}
 
-	// Set additional headers
-	for key, value := range headers {
-		req.Header.Set(key, value)
+			req.Header.Set("Content-Type", contentType)
+			for key, values := range r.Header {
+				req.Header[http.CanonicalHeaderKey(key)] = values
+			}
+
+			return req, nil
+		})
+	}
+	if info := responseInfoFromContext(ctx); info != nil {
+		call = captureResponse(info, call)
 	}
+	return c.instrument(ctx, r.Method, r.Path, call)
+}
 
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, err
+// encodeBody encodes body according to the request method: GET and HEAD
+// requests carry it in the query string, other methods send it as JSON or,
+// when Config.BodyEncoding is BodyForm, as a urlencoded form.
+func (c *Client) encodeBody(method, url string, body interface{}) ([]byte, string, string, error) {
+	if body == nil {
+		return nil, "application/json", url, nil
 	}
 
-	if c.Config.Debug {
-		ihttp.LogResponse(resp)
+	switch {
+	case method == http.MethodGet || method == http.MethodHead:
+		values, err := ihttp.EncodeValues(body)
+		if err != nil {
+			return nil, "", "", err
+		}
+		return nil, "application/json", ihttp.AppendQuery(url, values), nil
+	case c.Config.BodyEncoding == BodyForm:
+		values, err := ihttp.EncodeValues(body)
+		if err != nil {
+			return nil, "", "", err
+		}
+		return []byte(values.Encode()), "application/x-www-form-urlencoded", url, nil
 	}
 
-	return resp, nil
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(body); err != nil {
+		return nil, "", "", err
+	}
+	return buf.Bytes(), "application/json", url, nil
 }
@@ -1,30 +1,115 @@
 package sdk
 
 import (
+	"log/slog"
+	"net/http"
 	"time"
 )
 
+// BodyEncoding selects how request bodies of non-GET requests are encoded
+type BodyEncoding int
+
+const (
+	// BodyJSON sends request bodies as JSON (default)
+	BodyJSON BodyEncoding = iota
+	// BodyForm sends request bodies as application/x-www-form-urlencoded
+	BodyForm
+)
+
 // Config holds client configuration
 type Config struct {
 	BaseURL    string
+	Token      string
 	Timeout    time.Duration
 	UserAgent  string
 	Insecure   bool
 	Debug      bool
 	RetryCount int
+
+	// HTTPClient, when set, is used as-is for all requests; Timeout and the
+	// transport settings below are ignored
+	HTTPClient *http.Client
+	// Transport, when set, carries all requests instead of a transport built
+	// from the settings below
+	Transport http.RoundTripper
+
+	// ProxyURL routes requests through an http, https or socks5 proxy. When
+	// empty the HTTP_PROXY, HTTPS_PROXY and NO_PROXY variables are honored.
+	ProxyURL string
+	// RootCAPEM adds PEM encoded CA certificates to the system pool
+	RootCAPEM []byte
+	// ClientCertPEM and ClientKeyPEM hold the PEM encoded client certificate
+	// and key for mutual TLS
+	ClientCertPEM []byte
+	ClientKeyPEM  []byte
+
+	// Connection pool settings, http.DefaultTransport values when 0
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	MaxConnsPerHost     int
+	IdleConnTimeout     time.Duration
+	KeepAlive           time.Duration
+	DisableKeepAlives   bool
+
+	// BodyEncoding selects the body format for POST requests; GET requests
+	// always encode their parameters into the query string
+	BodyEncoding BodyEncoding
+
+	// Middlewares wrap every request attempt, the first being the outermost.
+	// They run after the built-in User-Agent and token middlewares.
+	Middlewares []Middleware
+
+	// Logger receives one structured record per request attempt. When nil and
+	// Debug is set, records are written to stderr.
+	Logger *slog.Logger
+	// LogBodies adds redacted request and response bodies to log records
+	LogBodies bool
+	// RedactFields lists body and query keys hidden from logs, DefaultRedactFields when nil
+	RedactFields []string
+
+	// Tracer and Metrics instrument every API call, disabled when nil
+	Tracer  Tracer
+	Metrics Metrics
+
+	// Limits throttle requests on the client side, see Limit
+	Limits []Limit
+	// CircuitBreaker makes requests fail fast while the server is down, disabled when nil
+	CircuitBreaker *CircuitBreaker
+
+	// RetryWaitMin and RetryWaitMax bound the exponential backoff between retries
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+	// RetryPolicy decides which failures are retried, DefaultRetryPolicy when nil
+	RetryPolicy RetryPolicy
+	// OnAttempt observes every request attempt, including the final one
+	OnAttempt AttemptHook
 }
 
 // DefaultConfig returns default client configuration
 func DefaultConfig() *Config {
 	return &Config{
-		Timeout:    30 * time.Second,
-		UserAgent:  "dootask-golang-sdk/1.0",
-		Insecure:   false,
-		Debug:      false,
-		RetryCount: 3,
+		Timeout:      30 * time.Second,
+		UserAgent:    "dootask-golang-sdk/1.0",
+		Insecure:     false,
+		Debug:        false,
+		RetryCount:   3,
+		RetryWaitMin: 200 * time.Millisecond,
+		RetryWaitMax: 5 * time.Second,
 	}
 }
 
+// WithBaseURL sets the DooTask server URL used when NewClientWithConfig gets an empty baseURL
+func (c *Config) WithBaseURL(baseURL string) *Config {
+	c.BaseURL = baseURL
+	return c
+}
+
+// WithToken sets the initial authentication token
+func (c *Config) WithToken(token string) *Config {
+	c.Token = token
+	return c
+}
+
 // WithTimeout sets the timeout for HTTP requests
 func (c *Config) WithTimeout(timeout time.Duration) *Config {
 	c.Timeout = timeout
@@ -54,3 +139,116 @@ func (c *Config) WithRetryCount(count int) *Config {
 	c.RetryCount = count
 	return c
 }
+
+// WithHTTPClient makes the client send all requests through httpClient
+func (c *Config) WithHTTPClient(httpClient *http.Client) *Config {
+	c.HTTPClient = httpClient
+	return c
+}
+
+// WithTransport makes the client send all requests through transport
+func (c *Config) WithTransport(transport http.RoundTripper) *Config {
+	c.Transport = transport
+	return c
+}
+
+// WithProxy sets the proxy URL, e.g. http://proxy.corp:3128
+func (c *Config) WithProxy(proxyURL string) *Config {
+	c.ProxyURL = proxyURL
+	return c
+}
+
+// WithRootCAs trusts the PEM encoded CA certificates in addition to the system pool
+func (c *Config) WithRootCAs(pem []byte) *Config {
+	c.RootCAPEM = pem
+	return c
+}
+
+// WithClientCertificate sets the PEM encoded client certificate and key for mutual TLS
+func (c *Config) WithClientCertificate(certPEM, keyPEM []byte) *Config {
+	c.ClientCertPEM = certPEM
+	c.ClientKeyPEM = keyPEM
+	return c
+}
+
+// WithConnectionPool sets the idle connection limits and idle timeout
+func (c *Config) WithConnectionPool(maxIdle, maxIdlePerHost int, idleTimeout time.Duration) *Config {
+	c.MaxIdleConns = maxIdle
+	c.MaxIdleConnsPerHost = maxIdlePerHost
+	c.IdleConnTimeout = idleTimeout
+	return c
+}
+
+// WithKeepAlive sets the TCP keep-alive period; disable turns off HTTP keep-alives
+func (c *Config) WithKeepAlive(period time.Duration, disable bool) *Config {
+	c.KeepAlive = period
+	c.DisableKeepAlives = disable
+	return c
+}
+
+// WithMiddleware appends middlewares to the request chain
+func (c *Config) WithMiddleware(middlewares ...Middleware) *Config {
+	c.Middlewares = append(c.Middlewares, middlewares...)
+	return c
+}
+
+// WithLogger sets the structured logger for request logging
+func (c *Config) WithLogger(logger *slog.Logger) *Config {
+	c.Logger = logger
+	return c
+}
+
+// WithLogBodies enables logging of redacted request and response bodies
+func (c *Config) WithLogBodies(logBodies bool) *Config {
+	c.LogBodies = logBodies
+	return c
+}
+
+// WithTracer sets the tracer that receives a span per API call
+func (c *Config) WithTracer(tracer Tracer) *Config {
+	c.Tracer = tracer
+	return c
+}
+
+// WithMetrics sets the sink for request counters and latency histograms
+func (c *Config) WithMetrics(metrics Metrics) *Config {
+	c.Metrics = metrics
+	return c
+}
+
+// WithLimit adds a client-side rate or concurrency limit
+func (c *Config) WithLimit(limit Limit) *Config {
+	c.Limits = append(c.Limits, limit)
+	return c
+}
+
+// WithCircuitBreaker enables the circuit breaker
+func (c *Config) WithCircuitBreaker(breaker CircuitBreaker) *Config {
+	c.CircuitBreaker = &breaker
+	return c
+}
+
+// WithBodyEncoding sets how POST request bodies are encoded
+func (c *Config) WithBodyEncoding(encoding BodyEncoding) *Config {
+	c.BodyEncoding = encoding
+	return c
+}
+
+// WithRetryBackoff sets the minimum and maximum wait between retries
+func (c *Config) WithRetryBackoff(min, max time.Duration) *Config {
+	c.RetryWaitMin = min
+	c.RetryWaitMax = max
+	return c
+}
+
+// WithRetryPolicy sets the policy that decides which failures are retried
+func (c *Config) WithRetryPolicy(policy RetryPolicy) *Config {
+	c.RetryPolicy = policy
+	return c
+}
+
+// WithAttemptHook sets a hook invoked after every request attempt
+func (c *Config) WithAttemptHook(hook AttemptHook) *Config {
+	c.OnAttempt = hook
+	return c
+}
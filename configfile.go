@@ -0,0 +1,308 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/xxyijixx/dootask-golang-sdk/sdkerr"
+)
+
+// Environment variables read by ConfigFromEnv and LoadConfig
+const (
+	EnvBaseURL      = "DOOTASK_BASE_URL"
+	EnvToken        = "DOOTASK_TOKEN"
+	EnvTimeout      = "DOOTASK_TIMEOUT"
+	EnvUserAgent    = "DOOTASK_USER_AGENT"
+	EnvInsecure     = "DOOTASK_INSECURE"
+	EnvDebug        = "DOOTASK_DEBUG"
+	EnvRetryCount   = "DOOTASK_RETRY_COUNT"
+	EnvRetryWaitMin = "DOOTASK_RETRY_WAIT_MIN"
+	EnvRetryWaitMax = "DOOTASK_RETRY_WAIT_MAX"
+	EnvLogBodies    = "DOOTASK_LOG_BODIES"
+	EnvProxyURL     = "DOOTASK_PROXY_URL"
+	EnvCAFile       = "DOOTASK_CA_FILE"
+	EnvCertFile     = "DOOTASK_CERT_FILE"
+	EnvKeyFile      = "DOOTASK_KEY_FILE"
+)
+
+// fileConfig mirrors the settable Config fields in config files. Pointer
+// fields distinguish "not set" from zero values so files only override what
+// they mention.
+type fileConfig struct {
+	BaseURL      *string   `json:"base_url" yaml:"base_url" toml:"base_url"`
+	Token        *string   `json:"token" yaml:"token" toml:"token"`
+	Timeout      *duration `json:"timeout" yaml:"timeout" toml:"timeout"`
+	UserAgent    *string   `json:"user_agent" yaml:"user_agent" toml:"user_agent"`
+	Insecure     *bool     `json:"insecure" yaml:"insecure" toml:"insecure"`
+	Debug        *bool     `json:"debug" yaml:"debug" toml:"debug"`
+	RetryCount   *int      `json:"retry_count" yaml:"retry_count" toml:"retry_count"`
+	RetryWaitMin *duration `json:"retry_wait_min" yaml:"retry_wait_min" toml:"retry_wait_min"`
+	RetryWaitMax *duration `json:"retry_wait_max" yaml:"retry_wait_max" toml:"retry_wait_max"`
+	LogBodies    *bool     `json:"log_bodies" yaml:"log_bodies" toml:"log_bodies"`
+
+	ProxyURL            *string   `json:"proxy_url" yaml:"proxy_url" toml:"proxy_url"`
+	CAFile              *string   `json:"ca_file" yaml:"ca_file" toml:"ca_file"`
+	CertFile            *string   `json:"cert_file" yaml:"cert_file" toml:"cert_file"`
+	KeyFile             *string   `json:"key_file" yaml:"key_file" toml:"key_file"`
+	MaxIdleConns        *int      `json:"max_idle_conns" yaml:"max_idle_conns" toml:"max_idle_conns"`
+	MaxIdleConnsPerHost *int      `json:"max_idle_conns_per_host" yaml:"max_idle_conns_per_host" toml:"max_idle_conns_per_host"`
+	MaxConnsPerHost     *int      `json:"max_conns_per_host" yaml:"max_conns_per_host" toml:"max_conns_per_host"`
+	IdleConnTimeout     *duration `json:"idle_conn_timeout" yaml:"idle_conn_timeout" toml:"idle_conn_timeout"`
+	KeepAlive           *duration `json:"keep_alive" yaml:"keep_alive" toml:"keep_alive"`
+	DisableKeepAlives   *bool     `json:"disable_keep_alives" yaml:"disable_keep_alives" toml:"disable_keep_alives"`
+}
+
+// duration parses Go duration strings such as "30s", or plain integers as seconds
+type duration time.Duration
+
+func (d *duration) UnmarshalText(text []byte) error {
+	v, err := parseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = duration(v)
+	return nil
+}
+
+// UnmarshalJSON accepts both "30s" and 30, which UnmarshalText alone rejects
+func (d *duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var n json.Number
+		if json.Unmarshal(data, &n) != nil {
+			return fmt.Errorf("duration must be a string or an integer, got %s", data)
+		}
+		s = n.String()
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+// ConfigFromEnv returns DefaultConfig overridden by the DOOTASK_* environment variables
+func ConfigFromEnv() (*Config, error) {
+	config := DefaultConfig()
+	if err := config.applyEnv(); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// LoadConfig reads a YAML, JSON or TOML config file, chosen by extension.
+//
+// Precedence, lowest to highest: DefaultConfig, the file, DOOTASK_* environment
+// variables. Only keys present in the file or set in the environment override
+// the previous layer.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var fc fileConfig
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&fc)
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&fc)
+	case ".toml":
+		var md toml.MetaData
+		md, err = toml.Decode(string(data), &fc)
+		if err == nil && len(md.Undecoded()) > 0 {
+			err = fmt.Errorf("unknown keys %v", md.Undecoded())
+		}
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse config %s: %w", path, err)
+	}
+
+	config := DefaultConfig()
+	if err := config.applyFile(&fc, filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+	if err := config.applyEnv(); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Validate reports invalid settings as a *sdkerr.ValidationError
+func (c *Config) Validate() error {
+	var problems []string
+
+	if c.BaseURL == "" {
+		problems = append(problems, "base_url: required")
+	} else if u, err := url.Parse(c.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		problems = append(problems, "base_url: must be an absolute http(s) URL")
+	}
+	if c.Timeout < 0 {
+		problems = append(problems, "timeout: must not be negative")
+	}
+	if c.RetryCount < 0 {
+		problems = append(problems, "retry_count: must not be negative")
+	}
+	if c.RetryWaitMin < 0 || c.RetryWaitMax < 0 {
+		problems = append(problems, "retry_wait: must not be negative")
+	} else if c.RetryWaitMax < c.RetryWaitMin {
+		problems = append(problems, "retry_wait_max: must not be less than retry_wait_min")
+	}
+	problems = append(problems, c.validateTransport()...)
+
+	if len(problems) > 0 {
+		return &sdkerr.ValidationError{Fields: problems, Msg: "invalid config"}
+	}
+	return nil
+}
+
+// applyFile overrides c with the keys set in fc. Relative certificate paths
+// are resolved against dir, the directory of the config file.
+func (c *Config) applyFile(fc *fileConfig, dir string) error {
+	if fc.BaseURL != nil {
+		c.BaseURL = *fc.BaseURL
+	}
+	if fc.Token != nil {
+		c.Token = *fc.Token
+	}
+	if fc.Timeout != nil {
+		c.Timeout = time.Duration(*fc.Timeout)
+	}
+	if fc.UserAgent != nil {
+		c.UserAgent = *fc.UserAgent
+	}
+	if fc.Insecure != nil {
+		c.Insecure = *fc.Insecure
+	}
+	if fc.Debug != nil {
+		c.Debug = *fc.Debug
+	}
+	if fc.RetryCount != nil {
+		c.RetryCount = *fc.RetryCount
+	}
+	if fc.RetryWaitMin != nil {
+		c.RetryWaitMin = time.Duration(*fc.RetryWaitMin)
+	}
+	if fc.RetryWaitMax != nil {
+		c.RetryWaitMax = time.Duration(*fc.RetryWaitMax)
+	}
+	if fc.LogBodies != nil {
+		c.LogBodies = *fc.LogBodies
+	}
+	if fc.ProxyURL != nil {
+		c.ProxyURL = *fc.ProxyURL
+	}
+	if fc.MaxIdleConns != nil {
+		c.MaxIdleConns = *fc.MaxIdleConns
+	}
+	if fc.MaxIdleConnsPerHost != nil {
+		c.MaxIdleConnsPerHost = *fc.MaxIdleConnsPerHost
+	}
+	if fc.MaxConnsPerHost != nil {
+		c.MaxConnsPerHost = *fc.MaxConnsPerHost
+	}
+	if fc.IdleConnTimeout != nil {
+		c.IdleConnTimeout = time.Duration(*fc.IdleConnTimeout)
+	}
+	if fc.KeepAlive != nil {
+		c.KeepAlive = time.Duration(*fc.KeepAlive)
+	}
+	if fc.DisableKeepAlives != nil {
+		c.DisableKeepAlives = *fc.DisableKeepAlives
+	}
+
+	for _, f := range []struct {
+		path *string
+		dst  *[]byte
+	}{
+		{fc.CAFile, &c.RootCAPEM},
+		{fc.CertFile, &c.ClientCertPEM},
+		{fc.KeyFile, &c.ClientKeyPEM},
+	} {
+		if f.path == nil {
+			continue
+		}
+		path := *f.path
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		pem, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		*f.dst = pem
+	}
+	return nil
+}
+
+func (c *Config) applyEnv() error {
+	var problems []string
+	lookup := func(name string, apply func(string) error) {
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			return
+		}
+		if err := apply(v); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", name, err))
+		}
+	}
+	str := func(dst *string) func(string) error {
+		return func(v string) error { *dst = v; return nil }
+	}
+	boolean := func(dst *bool) func(string) error {
+		return func(v string) (err error) { *dst, err = strconv.ParseBool(v); return }
+	}
+	dur := func(dst *time.Duration) func(string) error {
+		return func(v string) (err error) { *dst, err = parseDuration(v); return }
+	}
+	file := func(dst *[]byte) func(string) error {
+		return func(v string) (err error) { *dst, err = os.ReadFile(v); return }
+	}
+
+	lookup(EnvBaseURL, str(&c.BaseURL))
+	lookup(EnvToken, str(&c.Token))
+	lookup(EnvTimeout, dur(&c.Timeout))
+	lookup(EnvUserAgent, str(&c.UserAgent))
+	lookup(EnvInsecure, boolean(&c.Insecure))
+	lookup(EnvDebug, boolean(&c.Debug))
+	lookup(EnvRetryCount, func(v string) (err error) { c.RetryCount, err = strconv.Atoi(v); return })
+	lookup(EnvRetryWaitMin, dur(&c.RetryWaitMin))
+	lookup(EnvRetryWaitMax, dur(&c.RetryWaitMax))
+	lookup(EnvLogBodies, boolean(&c.LogBodies))
+	lookup(EnvProxyURL, str(&c.ProxyURL))
+	lookup(EnvCAFile, file(&c.RootCAPEM))
+	lookup(EnvCertFile, file(&c.ClientCertPEM))
+	lookup(EnvKeyFile, file(&c.ClientKeyPEM))
+
+	if len(problems) > 0 {
+		return &sdkerr.ValidationError{Fields: problems, Msg: "invalid environment"}
+	}
+	return nil
+}
+
+// parseDuration accepts Go duration strings and plain integers as seconds
+func parseDuration(v string) (time.Duration, error) {
+	v = strings.TrimSpace(v)
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second, nil
+	}
+	return time.ParseDuration(v)
+}
@@ -0,0 +1,330 @@
+package dootasktest
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/xxyijixx/dootask-golang-sdk/types"
+)
+
+type approval struct {
+	types.ApproveInstance
+	def  *types.ApproveProcDef
+	node int // index of the current node in def.Resource.Nodes()
+}
+
+// ProcDef returns the latest version of the process definition called name
+func (s *Server) ProcDef(name string) (types.ApproveProcDef, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if def := s.latestProcDef(name); def != nil {
+		return *def, true
+	}
+	return types.ApproveProcDef{}, false
+}
+
+// Approval returns the approval instance with the given ID
+func (s *Server) Approval(id int) (types.ApproveInstance, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, ok := s.approvals[id]
+	if !ok {
+		return types.ApproveInstance{}, false
+	}
+	return a.ApproveInstance, true
+}
+
+func (s *Server) latestProcDef(name string) *types.ApproveProcDef {
+	var latest *types.ApproveProcDef
+	for _, def := range s.procDefs {
+		if def.Name == name && (latest == nil || def.Version > latest.Version) {
+			latest = def
+		}
+	}
+	return latest
+}
+
+// nodeUsers resolves the users of an approver or notifier node for an
+// approval started in department
+func (s *Server) nodeUsers(node *types.ApproveNode, department int) []int {
+	var ids []int
+	for _, u := range node.NodeUserList {
+		id := u.TargetID
+		if u.Type == "leader" {
+			if d, ok := s.depts[department]; ok {
+				id = d.OwnerUserID
+			}
+		}
+		if id > 0 && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// record appends an entry to the history of a
+func (s *Server) record(a *approval, userID int, action, comment string) {
+	now := s.now()
+	a.History = append(a.History, types.ApproveHistory{
+		ID:         s.id(),
+		ProcInstID: a.ID,
+		TaskID:     a.TaskID,
+		NodeID:     a.NodeID,
+		UserID:     userID,
+		Username:   s.userInfo(userID).Nickname,
+		Action:     action,
+		Comment:    comment,
+		CreatedAt:  &now,
+	})
+}
+
+// advance moves a to the next approver node, finishing it as passed when
+// there is none
+func (s *Server) advance(a *approval) {
+	nodes := a.def.Resource.Nodes()
+	for a.node++; a.node < len(nodes); a.node++ {
+		node := nodes[a.node]
+		if node.Type != types.ApproveNodeApprover {
+			continue
+		}
+		a.NodeID = node.NodeID
+		a.TaskID = s.id()
+		a.Candidates = s.nodeUsers(node, a.DepartmentID)
+		return
+	}
+	s.finish(a, types.ApproveStatePassed)
+}
+
+func (s *Server) finish(a *approval, state int) {
+	now := s.now()
+	a.State = state
+	a.IsFinished = true
+	a.TaskID = 0
+	a.Candidates = []int{}
+	a.EndTime = &now
+}
+
+// summary returns a without the fields only the detail endpoint includes
+func (a *approval) summary() types.ApproveInstance {
+	out := a.ApproveInstance
+	out.Candidates = slices.Clone(a.Candidates)
+	out.History = nil
+	out.Resource = nil
+	return out
+}
+
+// canView reports whether userID started a or takes part in its process
+func (s *Server) canView(a *approval, userID int) bool {
+	if a.StartUserID == userID {
+		return true
+	}
+	for _, node := range a.def.Resource.Nodes() {
+		if slices.Contains(s.nodeUsers(node, a.DepartmentID), userID) {
+			return true
+		}
+	}
+	return false
+}
+
+// approvalList returns the approvals matching keep and the proc_name and
+// state parameters, newest first
+func (s *Server) approvalList(r *Request, keep func(a *approval) bool) types.ApproveListResponse {
+	procName, state := r.String("proc_name"), r.Int("state")
+	var list []types.ApproveInstance
+	for _, a := range s.approvals {
+		if procName != "" && a.ProcDefName != procName {
+			continue
+		}
+		if state > 0 && a.State != state {
+			continue
+		}
+		if keep(a) {
+			list = append(list, a.summary())
+		}
+	}
+	slices.SortFunc(list, func(a, b types.ApproveInstance) int { return b.ID - a.ID })
+	return paginate(r, list, 10)
+}
+
+func (s *Server) registerApprove() {
+	h := s.handlers
+
+	h["/api/approve/procdef/all"] = func(r *Request) (any, error) {
+		name := r.String("name")
+		list := []types.ApproveProcDef{}
+		for _, def := range s.procDefs {
+			if latest := s.latestProcDef(def.Name); latest != def {
+				continue
+			}
+			if name == "" || strings.Contains(def.Name, name) {
+				list = append(list, *def)
+			}
+		}
+		slices.SortFunc(list, func(a, b types.ApproveProcDef) int { return a.ID - b.ID })
+		return list, nil
+	}
+
+	h["/api/approve/procdef/save"] = func(r *Request) (any, error) {
+		if err := s.requireAdmin(r); err != nil {
+			return nil, err
+		}
+		var def types.ApproveProcDef
+		if err := json.Unmarshal(r.Body, &def); err != nil {
+			return nil, Errorf("参数错误")
+		}
+		if def.Name == "" {
+			return nil, Errorf("流程名称不能为空")
+		}
+		if def.Resource == nil || def.Resource.Type != types.ApproveNodeStart {
+			return nil, Errorf("流程缺少发起人节点")
+		}
+		for _, node := range def.Resource.Nodes() {
+			if node.Type == types.ApproveNodeApprover && len(node.NodeUserList) == 0 {
+				return nil, Errorf("审批节点【%s】未设置审批人", node.Name)
+			}
+		}
+		now := s.now()
+		def.ID = s.id()
+		def.Version = 1
+		def.UserID = r.UserID
+		def.Username = s.userInfo(r.UserID).Nickname
+		def.DeployTime = &now
+		if latest := s.latestProcDef(def.Name); latest != nil {
+			def.Version = latest.Version + 1
+		}
+		s.procDefs[def.ID] = &def
+		return def, nil
+	}
+
+	h["/api/approve/procdef/del"] = func(r *Request) (any, error) {
+		if err := s.requireAdmin(r); err != nil {
+			return nil, err
+		}
+		def, ok := s.procDefs[r.Int("id")]
+		if !ok {
+			return nil, Errorf("流程不存在")
+		}
+		for id, d := range s.procDefs {
+			if d.Name == def.Name {
+				delete(s.procDefs, id)
+			}
+		}
+		return nil, nil
+	}
+
+	h["/api/approve/process/start"] = func(r *Request) (any, error) {
+		var req types.ApproveStartRequest
+		if err := json.Unmarshal(r.Body, &req); err != nil {
+			return nil, Errorf("参数错误")
+		}
+		def := s.latestProcDef(req.ProcName)
+		if def == nil {
+			return nil, Errorf("流程不存在")
+		}
+		starter := s.users[r.UserID]
+		if req.DepartmentID > 0 && !slices.Contains(starter.Department, req.DepartmentID) {
+			return nil, Errorf("请选择你所在的部门")
+		}
+		now := s.now()
+		a := &approval{def: def}
+		a.ApproveInstance = types.ApproveInstance{
+			ID:            s.id(),
+			ProcDefID:     def.ID,
+			ProcDefName:   def.Name,
+			Title:         fmt.Sprintf("%s的%s", starter.Nickname, def.Name),
+			DepartmentID:  req.DepartmentID,
+			StartUserID:   r.UserID,
+			StartUserName: starter.Nickname,
+			NodeID:        def.Resource.NodeID,
+			Candidates:    []int{},
+			State:         types.ApproveStateRunning,
+			Var:           req.Var,
+			StartTime:     &now,
+		}
+		s.record(a, r.UserID, "start", "")
+		s.advance(a)
+		s.approvals[a.ID] = a
+		return a.summary(), nil
+	}
+
+	h["/api/approve/process/findTask"] = func(r *Request) (any, error) {
+		return s.approvalList(r, func(a *approval) bool {
+			return slices.Contains(a.Candidates, r.UserID)
+		}), nil
+	}
+
+	h["/api/approve/process/startByMyself"] = func(r *Request) (any, error) {
+		return s.approvalList(r, func(a *approval) bool {
+			return a.StartUserID == r.UserID
+		}), nil
+	}
+
+	h["/api/approve/process/doneList"] = func(r *Request) (any, error) {
+		return s.approvalList(r, func(a *approval) bool {
+			return slices.ContainsFunc(a.History, func(e types.ApproveHistory) bool {
+				return e.UserID == r.UserID && (e.Action == "pass" || e.Action == "refuse")
+			})
+		}), nil
+	}
+
+	h["/api/approve/process/detail"] = func(r *Request) (any, error) {
+		a, ok := s.approvals[r.Int("id")]
+		if !ok {
+			return nil, Errorf("审批不存在")
+		}
+		if !s.canView(a, r.UserID) {
+			return nil, Errorf("无权限查看该审批")
+		}
+		out := a.ApproveInstance
+		out.Candidates = slices.Clone(a.Candidates)
+		out.History = slices.Clone(a.History)
+		out.Resource = a.def.Resource
+		return out, nil
+	}
+
+	h["/api/approve/task/complete"] = func(r *Request) (any, error) {
+		var a *approval
+		for _, item := range s.approvals {
+			if item.TaskID != 0 && item.TaskID == r.Int("task_id") {
+				a = item
+			}
+		}
+		if a == nil {
+			return nil, Errorf("审批任务不存在或已处理")
+		}
+		if !slices.Contains(a.Candidates, r.UserID) {
+			return nil, Errorf("无权限审批该任务")
+		}
+		comment := r.String("comment")
+		if r.String("pass") != "true" {
+			s.record(a, r.UserID, "refuse", comment)
+			s.finish(a, types.ApproveStateRejected)
+			return a.summary(), nil
+		}
+		s.record(a, r.UserID, "pass", comment)
+		a.Candidates = slices.DeleteFunc(a.Candidates, func(id int) bool { return id == r.UserID })
+		node := a.def.Resource.Nodes()[a.node]
+		if node.ExamineMode != "and" || len(a.Candidates) == 0 {
+			s.advance(a)
+		}
+		return a.summary(), nil
+	}
+
+	h["/api/approve/task/withdraw"] = func(r *Request) (any, error) {
+		a, ok := s.approvals[r.Int("proc_inst_id")]
+		if !ok {
+			return nil, Errorf("审批不存在")
+		}
+		if a.StartUserID != r.UserID {
+			return nil, Errorf("仅发起人可以撤回")
+		}
+		if a.IsFinished || a.TaskID != r.Int("task_id") {
+			return nil, Errorf("审批已结束或已流转，无法撤回")
+		}
+		s.record(a, r.UserID, "withdraw", "")
+		s.finish(a, types.ApproveStateWithdrawn)
+		return nil, nil
+	}
+}
@@ -0,0 +1,277 @@
+package dootasktest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"unicode/utf8"
+
+	ihttp "github.com/xxyijixx/dootask-golang-sdk/internal/http"
+)
+
+// EnvRecord makes UseCassette record against the real server when set to a
+// non-empty value
+const EnvRecord = "DOOTASK_RECORD"
+
+// RedactFields lists the query and JSON body keys replaced in cassettes:
+// credentials, so cassettes can be committed
+var RedactFields = []string{"token", "password", "oldpass", "newpass", "passwd"}
+
+// droppedHeaders are not recorded: credentials, and the length that
+// redaction may change
+var droppedHeaders = []string{"Set-Cookie", "Token", "Authorization", "Content-Length"}
+
+// Cassette is the on-disk form of recorded DooTask traffic
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is one recorded request/response pair
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest holds the parts of a request replay matches on. Query and
+// Body are stored redacted and normalized.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	Body   string `json:"body,omitempty"`
+}
+
+// RecordedResponse is a recorded response. Binary bodies are stored base64
+// encoded with Encoding set to "base64".
+type RecordedResponse struct {
+	Status   int         `json:"status"`
+	Header   http.Header `json:"header,omitempty"`
+	Body     string      `json:"body"`
+	Encoding string      `json:"encoding,omitempty"`
+}
+
+// LoadCassette reads a cassette file
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("load cassette: %w", err)
+	}
+	var c Cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("load cassette %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// Save writes the cassette to path, creating parent directories as needed
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("save cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("save cassette: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("save cassette: %w", err)
+	}
+	return nil
+}
+
+// Recorder is an http.RoundTripper that forwards requests to a real server
+// and records every exchange. Tokens and passwords never reach the cassette.
+type Recorder struct {
+	next http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// NewRecorder returns a Recorder forwarding to next, http.DefaultTransport
+// when nil. Pass it to sdk.Config.WithTransport and call Save when done.
+func NewRecorder(next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{next: next}
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	header := resp.Header.Clone()
+	for _, name := range droppedHeaders {
+		header.Del(name)
+	}
+	response := RecordedResponse{Status: resp.StatusCode, Header: header}
+	if redacted, ok := ihttp.RedactJSON(body, RedactFields); ok {
+		response.Body = string(redacted)
+	} else if utf8.Valid(body) {
+		response.Body = string(body)
+	} else {
+		response.Body = base64.StdEncoding.EncodeToString(body)
+		response.Encoding = "base64"
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{Request: recorded, Response: response})
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// Cassette returns a copy of the interactions recorded so far
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &Cassette{Interactions: append([]Interaction(nil), r.cassette.Interactions...)}
+}
+
+// Save writes the recorded interactions to path
+func (r *Recorder) Save(path string) error {
+	return r.Cassette().Save(path)
+}
+
+// Replayer is an http.RoundTripper that answers requests from a cassette
+// without network access. A request matches an interaction with the same
+// method, path, query and body; identical requests are answered in recorded
+// order, so flows like list, add, list replay deterministically.
+type Replayer struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewReplayer returns a Replayer serving the cassette at path
+func NewReplayer(path string) (*Replayer, error) {
+	c, err := LoadCassette(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewCassetteReplayer(c), nil
+}
+
+// NewCassetteReplayer returns a Replayer serving c
+func NewCassetteReplayer(c *Cassette) *Replayer {
+	return &Replayer{interactions: c.Interactions, used: make([]bool, len(c.Interactions))}
+}
+
+// RoundTrip implements http.RoundTripper. Unmatched requests fail with an
+// error naming the request.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	match := -1
+	for i, in := range r.interactions {
+		if !r.used[i] && in.Request == recorded {
+			match = i
+			r.used[i] = true
+			break
+		}
+	}
+	r.mu.Unlock()
+	if match < 0 {
+		return nil, fmt.Errorf("dootasktest: no recorded interaction for %s %s", req.Method, ihttp.RedactRequestURI(req.URL, RedactFields))
+	}
+
+	recordedResp := r.interactions[match].Response
+	body := []byte(recordedResp.Body)
+	if recordedResp.Encoding == "base64" {
+		if body, err = base64.StdEncoding.DecodeString(recordedResp.Body); err != nil {
+			return nil, fmt.Errorf("dootasktest: corrupt cassette body: %w", err)
+		}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recordedResp.Status, http.StatusText(recordedResp.Status)),
+		StatusCode:    recordedResp.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recordedResp.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// Unused returns the interactions that have not been replayed yet
+func (r *Replayer) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []Interaction
+	for i, in := range r.interactions {
+		if !r.used[i] {
+			out = append(out, in)
+		}
+	}
+	return out
+}
+
+// UseCassette returns a transport for sdk.Config.WithTransport. When
+// DOOTASK_RECORD is set it records against the real server and saves the
+// cassette at path once the test ends; otherwise it replays path and fails
+// the test when the cassette is missing.
+func UseCassette(tb testing.TB, path string) http.RoundTripper {
+	tb.Helper()
+	if os.Getenv(EnvRecord) != "" {
+		rec := NewRecorder(nil)
+		tb.Cleanup(func() {
+			if err := rec.Save(path); err != nil {
+				tb.Error(err)
+			}
+		})
+		return rec
+	}
+
+	rep, err := NewReplayer(path)
+	if err != nil {
+		tb.Fatalf("%v (set %s=1 to record it)", err, EnvRecord)
+	}
+	return rep
+}
+
+// recordRequest returns the redacted, normalized form of req used for
+// matching. The request body is read and restored.
+func recordRequest(req *http.Request) (RecordedRequest, error) {
+	recorded := RecordedRequest{Method: req.Method, Path: req.URL.Path}
+	if req.URL.RawQuery != "" {
+		recorded.Query = ihttp.RedactValues(req.URL.Query(), RedactFields).Encode()
+	}
+	if req.Body == nil || req.Body == http.NoBody {
+		return recorded, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return recorded, fmt.Errorf("dootasktest: read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	recorded.Body = ihttp.RedactBody(body, RedactFields, 0)
+	return recorded, nil
+}
@@ -0,0 +1,246 @@
+package dootasktest
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func newTestRequest(t *testing.T, method, target, body string) *http.Request {
+	t.Helper()
+	var r io.Reader
+	if body != "" {
+		r = strings.NewReader(body)
+	}
+	req, err := http.NewRequest(method, "http://dootask.test"+target, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return req
+}
+
+func readBody(t *testing.T, resp *http.Response) string {
+	t.Helper()
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestReplayerMatching(t *testing.T) {
+	cassette := &Cassette{Interactions: []Interaction{
+		{RecordedRequest{Method: "GET", Path: "/api/users/info"},
+			RecordedResponse{Status: 200, Body: "info"}},
+		{RecordedRequest{Method: "GET", Path: "/api/users/search", Query: "keys%5Bkey%5D=bob&take=10"},
+			RecordedResponse{Status: 200, Body: "search"}},
+		{RecordedRequest{Method: "GET", Path: "/api/dialog/lists", Query: "token=%5BREDACTED%5D"},
+			RecordedResponse{Status: 200, Body: "dialogs"}},
+		{RecordedRequest{Method: "POST", Path: "/api/users/login", Body: `{"email":"a@b.c","password":"[REDACTED]"}`},
+			RecordedResponse{Status: 200, Body: "login"}},
+		{RecordedRequest{Method: "POST", Path: "/api/report/store", Body: "title=weekly&type=week"},
+			RecordedResponse{Status: 200, Body: "report"}},
+	}}
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		want   string // response body, empty when the request must not match
+	}{
+		{"exact", "GET", "/api/users/info", "", "info"},
+		{"query order is normalized", "GET", "/api/users/search?take=10&keys%5Bkey%5D=bob", "", "search"},
+		{"query value differs", "GET", "/api/users/search?take=20&keys%5Bkey%5D=bob", "", ""},
+		{"extra query parameter", "GET", "/api/users/info?x=1", "", ""},
+		{"redacted query matches any token", "GET", "/api/dialog/lists?token=secret", "", "dialogs"},
+		{"method differs", "POST", "/api/users/info", "", ""},
+		{"path differs", "GET", "/api/users/infos", "", ""},
+		{"redacted JSON body", "POST", "/api/users/login", `{"password":"hunter2", "email":"a@b.c"}`, "login"},
+		{"JSON body differs", "POST", "/api/users/login", `{"email":"x@b.c","password":"hunter2"}`, ""},
+		{"form body order is normalized", "POST", "/api/report/store", "type=week&title=weekly", "report"},
+		{"missing body", "POST", "/api/report/store", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rep := NewCassetteReplayer(cassette)
+			resp, err := rep.RoundTrip(newTestRequest(t, tt.method, tt.target, tt.body))
+			if tt.want == "" {
+				if err == nil {
+					t.Fatalf("matched %q, want no match", readBody(t, resp))
+				}
+				if !strings.Contains(err.Error(), "no recorded interaction") {
+					t.Errorf("error = %v, want a no recorded interaction error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RoundTrip: %v", err)
+			}
+			if got := readBody(t, resp); got != tt.want {
+				t.Errorf("body = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplayerRecordedOrder(t *testing.T) {
+	list := RecordedRequest{Method: "GET", Path: "/api/project/lists"}
+	add := RecordedRequest{Method: "GET", Path: "/api/project/add", Query: "name=p"}
+	rep := NewCassetteReplayer(&Cassette{Interactions: []Interaction{
+		{list, RecordedResponse{Status: 200, Body: "empty"}},
+		{add, RecordedResponse{Status: 200, Body: "added"}},
+		{list, RecordedResponse{Status: 200, Body: "one"}},
+	}})
+
+	steps := []struct {
+		target string
+		want   string
+	}{
+		{"/api/project/lists", "empty"},
+		{"/api/project/add?name=p", "added"},
+		{"/api/project/lists", "one"},
+		{"/api/project/lists", ""},
+	}
+	for i, step := range steps {
+		resp, err := rep.RoundTrip(newTestRequest(t, "GET", step.target, ""))
+		if step.want == "" {
+			if err == nil {
+				t.Errorf("step %d: replayed %q, want the cassette exhausted", i, readBody(t, resp))
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+		if got := readBody(t, resp); got != step.want {
+			t.Errorf("step %d: body = %q, want %q", i, got, step.want)
+		}
+	}
+	if unused := rep.Unused(); len(unused) != 0 {
+		t.Errorf("Unused = %v, want none", unused)
+	}
+}
+
+func TestReplayerUnused(t *testing.T) {
+	rep := NewCassetteReplayer(&Cassette{Interactions: []Interaction{
+		{RecordedRequest{Method: "GET", Path: "/a"}, RecordedResponse{Status: 200}},
+		{RecordedRequest{Method: "GET", Path: "/b"}, RecordedResponse{Status: 200}},
+	}})
+	resp, err := rep.RoundTrip(newTestRequest(t, "GET", "/b", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	unused := rep.Unused()
+	if len(unused) != 1 || unused[0].Request.Path != "/a" {
+		t.Errorf("Unused = %v, want only /a", unused)
+	}
+}
+
+func TestReplayerConcurrent(t *testing.T) {
+	const n = 50
+	c := &Cassette{}
+	for i := 0; i < n; i++ {
+		c.Interactions = append(c.Interactions, Interaction{
+			RecordedRequest{Method: "GET", Path: "/api/users/info"},
+			RecordedResponse{Status: 200, Body: "info"},
+		})
+	}
+	rep := NewCassetteReplayer(c)
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := http.NewRequest("GET", "http://dootask.test/api/users/info", nil)
+			resp, err := rep.RoundTrip(req)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+	if unused := rep.Unused(); len(unused) != 0 {
+		t.Errorf("%d interactions unused, want each replayed once", len(unused))
+	}
+}
+
+func TestRecorderRoundTrip(t *testing.T) {
+	binary := []byte{0xff, 0x00, 0xfe}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/users/login":
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Set-Cookie", "session=secret")
+			w.Write([]byte(`{"ret":1,"msg":"","data":{"userid":1,"token":"server-token"}}`))
+		case "/api/file/content/down":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Write(binary)
+		}
+	}))
+	defer srv.Close()
+
+	rec := NewRecorder(nil)
+	client := &http.Client{Transport: rec}
+	login := func(rt http.RoundTripper, token, password string) string {
+		req, _ := http.NewRequest("POST", srv.URL+"/api/users/login?token="+token,
+			strings.NewReader(`{"email":"a@b.c","password":"`+password+`"}`))
+		resp, err := rt.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return readBody(t, resp)
+	}
+
+	if got := login(client.Transport, "client-token", "hunter2"); !strings.Contains(got, "server-token") {
+		t.Errorf("recorder changed the live response: %s", got)
+	}
+	resp, err := client.Get(srv.URL + "/api/file/content/down?id=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := readBody(t, resp); got != string(binary) {
+		t.Errorf("recorder changed the live binary body: %q", got)
+	}
+
+	path := filepath.Join(t.TempDir(), "nested", "cassette.json")
+	if err := rec.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"client-token", "hunter2", "server-token", "session=secret"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q", secret)
+		}
+	}
+
+	rep, err := NewReplayer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := login(rep, "other-token", "other-password"); !strings.Contains(got, `"token":"[REDACTED]"`) {
+		t.Errorf("replayed login = %s, want the redacted recording", got)
+	}
+	resp, err = rep.RoundTrip(newTestRequest(t, "GET", "/api/file/content/down?id=1", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := readBody(t, resp); got != string(binary) {
+		t.Errorf("replayed binary body = %q, want %q", got, binary)
+	}
+}
@@ -0,0 +1,777 @@
+package dootasktest
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/xxyijixx/dootask-golang-sdk/types"
+)
+
+// dialog is a conversation with its per-user state
+type dialog struct {
+	types.DialogItem
+	members []int
+	topAt   map[int]string // user ID to pin time
+	silence map[int]bool
+	color   map[int]string
+	lastAt  string
+}
+
+// Messages returns the messages of a dialog in ascending ID order
+func (s *Server) Messages(dialogID int) []types.MessageItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []types.MessageItem
+	for _, m := range s.dialogMessages(dialogID) {
+		out = append(out, *m)
+	}
+	return out
+}
+
+// AddMessage posts a text message to a dialog as userID and returns it
+func (s *Server) AddMessage(dialogID, userID int, text string) types.MessageItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.newMessage(s.dialogs[dialogID], userID, "text", map[string]any{"text": text, "type": "html"})
+}
+
+// OpenDialog returns the private dialog between two users, creating it if needed
+func (s *Server) OpenDialog(userID, otherID int) types.DialogItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dialogView(s.privateDialog(userID, otherID), userID)
+}
+
+func (s *Server) newDialog(typ, groupType, name string, ownerID int, members []int, linkID int) *dialog {
+	now := s.nowString()
+	d := &dialog{
+		topAt:   make(map[int]string),
+		silence: make(map[int]bool),
+		color:   make(map[int]string),
+		lastAt:  now,
+	}
+	d.ID = s.id()
+	d.Type = typ
+	d.Name = name
+	d.CreatedAt = &now
+	d.UpdatedAt = &now
+	if groupType != "" {
+		d.GroupType = &groupType
+	}
+	if ownerID != 0 {
+		d.OwnerID = &ownerID
+	}
+	if linkID != 0 {
+		d.LinkID = &linkID
+	}
+	for _, id := range members {
+		if !slices.Contains(d.members, id) {
+			d.members = append(d.members, id)
+		}
+	}
+	s.dialogs[d.ID] = d
+	return d
+}
+
+func (s *Server) privateDialog(userID, otherID int) *dialog {
+	for _, d := range s.dialogs {
+		if d.Type == "user" && len(d.members) == 2 &&
+			slices.Contains(d.members, userID) && slices.Contains(d.members, otherID) {
+			return d
+		}
+	}
+	return s.newDialog("user", "", "", 0, []int{userID, otherID}, 0)
+}
+
+func (s *Server) dialog(r *Request) (*dialog, error) {
+	d, ok := s.dialogs[r.Int("dialog_id")]
+	if !ok || !slices.Contains(d.members, r.UserID) {
+		return nil, Errorf("会话不存在或已被删除")
+	}
+	return d, nil
+}
+
+func (s *Server) message(r *Request) (*types.MessageItem, *dialog, error) {
+	m, ok := s.messages[r.Int("msg_id")]
+	if !ok {
+		return nil, nil, Errorf("消息不存在或已被删除")
+	}
+	d, ok := s.dialogs[m.DialogID]
+	if !ok || !slices.Contains(d.members, r.UserID) {
+		return nil, nil, Errorf("消息不存在或已被删除")
+	}
+	return m, d, nil
+}
+
+// dialogMessages returns the live messages of a dialog in ascending ID order
+func (s *Server) dialogMessages(dialogID int) []*types.MessageItem {
+	var out []*types.MessageItem
+	for _, m := range s.messages {
+		if m.DialogID == dialogID && m.DeletedAt == nil {
+			out = append(out, m)
+		}
+	}
+	slices.SortFunc(out, func(a, b *types.MessageItem) int { return cmp.Compare(a.ID, b.ID) })
+	return out
+}
+
+// markRead records that userID has read m
+func (s *Server) markRead(m *types.MessageItem, userID int) {
+	if m.UserID == userID {
+		return
+	}
+	readers := s.readers(m.ID)
+	if _, ok := readers[userID]; ok {
+		return
+	}
+	readers[userID] = s.nowString()
+	m.Read = len(readers)
+	if m.Send > 0 {
+		m.Percentage = m.Read * 100 / m.Send
+	}
+}
+
+// readers maps the IDs of users who read the message to the read time
+func (s *Server) readers(msgID int) map[int]string {
+	if s.reads[msgID] == nil {
+		s.reads[msgID] = make(map[int]string)
+	}
+	return s.reads[msgID]
+}
+
+func (s *Server) newMessage(d *dialog, userID int, typ string, msg map[string]any) *types.MessageItem {
+	now := s.nowString()
+	m := &types.MessageItem{
+		ID:         s.id(),
+		DialogID:   d.ID,
+		DialogType: d.Type,
+		UserID:     userID,
+		Type:       typ,
+		Mtype:      typ,
+		Msg:        msg,
+		Emoji:      []any{},
+		Send:       len(d.members) - 1,
+		CreatedAt:  now,
+	}
+	s.messages[m.ID] = m
+	d.lastAt = now
+	return m
+}
+
+func (s *Server) messageView(m *types.MessageItem) types.MessageItem {
+	view := *m
+	view.PrevID, view.NextID = 0, 0
+	msgs := s.dialogMessages(m.DialogID)
+	if i := slices.Index(msgs, m); i >= 0 {
+		if i > 0 {
+			view.PrevID = msgs[i-1].ID
+		}
+		if i < len(msgs)-1 {
+			view.NextID = msgs[i+1].ID
+		}
+	}
+	return view
+}
+
+func (s *Server) unread(d *dialog, userID int) types.UnreadMessageResponse {
+	res := types.UnreadMessageResponse{MentionIDs: []int{}}
+	for _, m := range s.dialogMessages(d.ID) {
+		if m.UserID == userID {
+			continue
+		}
+		if _, ok := s.readers(m.ID)[userID]; ok {
+			continue
+		}
+		res.Unread++
+		if res.UnreadOne == 0 {
+			res.UnreadOne = m.ID
+		}
+	}
+	return res
+}
+
+func (s *Server) dialogView(d *dialog, userID int) types.DialogItem {
+	view := d.DialogItem
+	lastAt := d.lastAt
+	view.LastAt = &lastAt
+	if top, ok := d.topAt[userID]; ok {
+		view.TopAt = &top
+	}
+	silence := 0
+	if d.silence[userID] {
+		silence = 1
+	}
+	view.Silence = &silence
+	if color, ok := d.color[userID]; ok {
+		view.Color = &color
+	}
+	unread := s.unread(d, userID)
+	view.Unread, view.UnreadOne = unread.Unread, unread.UnreadOne
+	view.MentionIDs = []int{}
+	view.People = len(d.members)
+	view.PeopleUser = len(d.members)
+	for _, t := range s.todos {
+		if t.DialogID == d.ID && t.UserID == userID && t.DoneAt == nil {
+			view.TodoNum++
+		}
+	}
+
+	if d.Type == "user" {
+		for _, id := range d.members {
+			if id != userID {
+				u := s.userInfo(id)
+				view.Name = u.Nickname
+				view.Email = &u.Email
+				view.DialogUser = &types.DialogUser{DialogID: d.ID, UserID: id, CreatedAt: *d.CreatedAt, UpdatedAt: *d.UpdatedAt}
+			}
+		}
+	}
+
+	if msgs := s.dialogMessages(d.ID); len(msgs) > 0 {
+		last := msgs[len(msgs)-1]
+		view.LastMsg = &types.LastMsg{
+			ID:        last.ID,
+			Type:      last.Type,
+			Msg:       last.Msg,
+			UserID:    last.UserID,
+			Emoji:     last.Emoji,
+			CreatedAt: last.CreatedAt,
+		}
+	}
+	return view
+}
+
+func (s *Server) peopleCount(d *dialog) types.AddGroupUserResponse {
+	return types.AddGroupUserResponse{People: len(d.members), PeopleUser: len(d.members)}
+}
+
+func (s *Server) registerDialog() {
+	h := s.handlers
+
+	h["/api/dialog/lists"] = func(r *Request) (any, error) {
+		var items []types.DialogItem
+		for _, d := range s.dialogs {
+			if slices.Contains(d.members, r.UserID) {
+				items = append(items, s.dialogView(d, r.UserID))
+			}
+		}
+		slices.SortFunc(items, func(a, b types.DialogItem) int {
+			if (a.TopAt != nil) != (b.TopAt != nil) {
+				if a.TopAt != nil {
+					return -1
+				}
+				return 1
+			}
+			return cmp.Or(strings.Compare(*b.LastAt, *a.LastAt), cmp.Compare(b.ID, a.ID))
+		})
+		return paginate(r, items, 50), nil
+	}
+
+	h["/api/dialog/search"] = func(r *Request) (any, error) {
+		key := r.String("key")
+		items := []types.DialogItem{}
+		for _, d := range s.dialogs {
+			if !slices.Contains(d.members, r.UserID) {
+				continue
+			}
+			if view := s.dialogView(d, r.UserID); strings.Contains(view.Name, key) {
+				items = append(items, view)
+			}
+		}
+		slices.SortFunc(items, func(a, b types.DialogItem) int { return cmp.Compare(a.ID, b.ID) })
+		return items[:min(len(items), cmp.Or(r.Int("take"), 20))], nil
+	}
+
+	h["/api/dialog/one"] = func(r *Request) (any, error) {
+		d, err := s.dialog(r)
+		if err != nil {
+			return nil, err
+		}
+		return s.dialogView(d, r.UserID), nil
+	}
+
+	h["/api/dialog/user"] = func(r *Request) (any, error) {
+		d, err := s.dialog(r)
+		if err != nil {
+			return nil, err
+		}
+		items := []types.DialogUserWithDetail{}
+		for _, id := range d.members {
+			item := types.DialogUserWithDetail{DialogID: d.ID, UserID: id, CreatedAt: *d.CreatedAt, UpdatedAt: *d.UpdatedAt}
+			if r.Int("getuser") == 1 {
+				u := s.userInfo(id)
+				item.UserID2, item.Nickname, item.Email, item.Userimg = u.ID, u.Nickname, u.Email, u.Avatar
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	}
+
+	h["/api/dialog/todo"] = func(r *Request) (any, error) {
+		items := []types.DialogTodoItem{}
+		for _, t := range s.todos {
+			if t.UserID == r.UserID && t.DoneAt == nil && (r.Int("dialog_id") == 0 || t.DialogID == r.Int("dialog_id")) {
+				items = append(items, *t)
+			}
+		}
+		return items, nil
+	}
+
+	h["/api/dialog/top"] = func(r *Request) (any, error) {
+		d, err := s.dialog(r)
+		if err != nil {
+			return nil, err
+		}
+		res := types.DialogTopResponse{ID: d.ID}
+		if _, ok := d.topAt[r.UserID]; ok {
+			delete(d.topAt, r.UserID)
+		} else {
+			now := s.nowString()
+			d.topAt[r.UserID] = now
+			res.TopAt = &now
+		}
+		return res, nil
+	}
+
+	h["/api/dialog/tel"] = func(r *Request) (any, error) {
+		if _, err := s.dialog(r); err != nil {
+			return nil, err
+		}
+		return nil, Errorf("对方未设置联系电话")
+	}
+
+	h["/api/dialog/open/user"] = func(r *Request) (any, error) {
+		otherID := r.Int("user_id")
+		if _, ok := s.users[otherID]; !ok {
+			return nil, Errorf("用户不存在")
+		}
+		return s.dialogView(s.privateDialog(r.UserID, otherID), r.UserID), nil
+	}
+
+	h["/api/dialog/msg/list"] = func(r *Request) (any, error) {
+		d, err := s.dialog(r)
+		if err != nil {
+			return nil, err
+		}
+		take := min(cmp.Or(r.Int("take"), 50), 100)
+		msgType := r.String("msg_type")
+		replyTo := r.Int("msg_id")
+
+		var msgs []*types.MessageItem
+		for _, m := range s.dialogMessages(d.ID) {
+			if msgType != "" && m.Mtype != msgType {
+				continue
+			}
+			if replyTo != 0 && (m.ReplyID == nil || *m.ReplyID != replyTo) {
+				continue
+			}
+			msgs = append(msgs, m)
+		}
+
+		// Newest first, except when paging forward from next_id
+		var page []*types.MessageItem
+		switch {
+		case r.Int("next_id") != 0:
+			for _, m := range msgs {
+				if m.ID > r.Int("next_id") && len(page) < take {
+					page = append(page, m)
+				}
+			}
+		case r.Int("prev_id") != 0:
+			for i := len(msgs) - 1; i >= 0; i-- {
+				if msgs[i].ID < r.Int("prev_id") && len(page) < take {
+					page = append(page, msgs[i])
+				}
+			}
+		case r.Int("position_id") != 0:
+			pos := r.Int("position_id")
+			var before, after []*types.MessageItem
+			for i := len(msgs) - 1; i >= 0; i-- {
+				if msgs[i].ID <= pos && len(before) < take/2+1 {
+					before = append(before, msgs[i])
+				}
+			}
+			for _, m := range msgs {
+				if m.ID > pos && len(after) < take/2 {
+					after = append([]*types.MessageItem{m}, after...)
+				}
+			}
+			page = append(after, before...)
+		default:
+			for i := len(msgs) - 1; i >= 0 && len(page) < take; i-- {
+				page = append(page, msgs[i])
+			}
+		}
+
+		res := types.MessageListResponse{List: []types.MessageItem{}, Time: s.Now().Unix(), Todo: []types.DialogTodoItem{}}
+		for _, m := range page {
+			s.markRead(m, r.UserID)
+			res.List = append(res.List, s.messageView(m))
+		}
+		view := s.dialogView(d, r.UserID)
+		res.Dialog = &view
+		for _, t := range s.todos {
+			if t.DialogID == d.ID && t.UserID == r.UserID && t.DoneAt == nil {
+				res.Todo = append(res.Todo, *t)
+			}
+		}
+		if d.TopMsgID != nil {
+			if top, ok := s.messages[*d.TopMsgID]; ok {
+				view := s.messageView(top)
+				res.Top = &view
+			}
+		}
+		return res, nil
+	}
+
+	h["/api/dialog/msg/search"] = func(r *Request) (any, error) {
+		key := r.String("key")
+		take := cmp.Or(r.Int("take"), 20)
+		res := types.SearchMessageResponse{List: []types.MessageItem{}}
+		for _, d := range s.dialogs {
+			if !slices.Contains(d.members, r.UserID) || (r.Int("dialog_id") != 0 && d.ID != r.Int("dialog_id")) {
+				continue
+			}
+			for _, m := range s.dialogMessages(d.ID) {
+				if text, _ := m.Msg["text"].(string); strings.Contains(text, key) && len(res.List) < take {
+					res.List = append(res.List, s.messageView(m))
+				}
+			}
+		}
+		if d, ok := s.dialogs[r.Int("dialog_id")]; ok && slices.Contains(d.members, r.UserID) {
+			view := s.dialogView(d, r.UserID)
+			res.Dialog = &view
+		}
+		return res, nil
+	}
+
+	oneMessage := func(r *Request) (any, error) {
+		m, _, err := s.message(r)
+		if err != nil {
+			return nil, err
+		}
+		return s.messageView(m), nil
+	}
+	h["/api/dialog/msg/one"] = oneMessage
+	h["/api/dialog/msg/detail"] = oneMessage
+	h["/api/dialog/msg/mark"] = oneMessage
+
+	h["/api/dialog/msg/read"] = func(r *Request) (any, error) {
+		d, err := s.dialog(r)
+		if err != nil {
+			return nil, err
+		}
+		var res types.ReadMessageResponse
+		for _, m := range s.dialogMessages(d.ID) {
+			if m.UserID != r.UserID {
+				s.markRead(m, r.UserID)
+				res.Read++
+			}
+		}
+		res.Send = res.Read
+		if res.Send > 0 {
+			res.Percentage = 100
+		}
+		return res, nil
+	}
+
+	h["/api/dialog/msg/unread"] = func(r *Request) (any, error) {
+		d, err := s.dialog(r)
+		if err != nil {
+			return nil, err
+		}
+		return s.unread(d, r.UserID), nil
+	}
+
+	send := func(r *Request, anonymous bool) (any, error) {
+		ids := []int{r.Int("dialog_id")}
+		if r.Has("dialog_ids") {
+			ids = r.Ints("dialog_ids")
+		}
+		text := r.String("text")
+		if strings.TrimSpace(text) == "" {
+			return nil, Errorf("消息内容不能为空")
+		}
+
+		if updateID := r.Int("update_id"); updateID != 0 {
+			m, ok := s.messages[updateID]
+			if !ok || m.UserID != r.UserID {
+				return nil, Errorf("消息不存在或已被删除")
+			}
+			m.Msg["text"] = text
+			m.Modify = 1
+			return s.messageView(m), nil
+		}
+
+		var last *types.MessageItem
+		for _, id := range ids {
+			d, ok := s.dialogs[id]
+			if !ok || !slices.Contains(d.members, r.UserID) {
+				return nil, Errorf("会话不存在或已被删除")
+			}
+			userID := r.UserID
+			if anonymous {
+				userID = 0
+			}
+			last = s.newMessage(d, userID, "text", map[string]any{"text": text, "type": cmp.Or(r.String("text_type"), "html")})
+			if replyID := r.Int("reply_id"); replyID != 0 {
+				if parent, ok := s.messages[replyID]; ok && parent.DialogID == d.ID {
+					parent.ReplyNum++
+					last.ReplyID = &replyID
+				}
+			}
+		}
+		return s.messageView(last), nil
+	}
+	h["/api/dialog/msg/sendtext"] = func(r *Request) (any, error) { return send(r, false) }
+	h["/api/dialog/msg/sendanon"] = func(r *Request) (any, error) { return send(r, true) }
+
+	h["/api/dialog/msg/readlist"] = func(r *Request) (any, error) {
+		m, d, err := s.message(r)
+		if err != nil {
+			return nil, err
+		}
+		items := types.MessageReadListResponse{}
+		readers := s.readers(m.ID)
+		for _, id := range d.members {
+			if id == m.UserID {
+				continue
+			}
+			u := s.userInfo(id)
+			item := types.MessageReadListResponse{{UserID: id, Nickname: u.Nickname, Userimg: u.Avatar}}
+			if at, ok := readers[id]; ok {
+				item[0].ReadAt = &at
+			}
+			items = append(items, item...)
+		}
+		return items, nil
+	}
+
+	h["/api/dialog/msg/withdraw"] = func(r *Request) (any, error) {
+		m, _, err := s.message(r)
+		if err != nil {
+			return nil, err
+		}
+		if m.UserID != r.UserID {
+			return nil, Errorf("仅限撤回自己的消息")
+		}
+		view := s.messageView(m)
+		now := s.nowString()
+		m.DeletedAt = &now
+		view.DeletedAt = &now
+		return view, nil
+	}
+
+	h["/api/dialog/msg/silence"] = func(r *Request) (any, error) {
+		d, err := s.dialog(r)
+		if err != nil {
+			return nil, err
+		}
+		d.silence[r.UserID] = !d.silence[r.UserID]
+		res := types.SilenceMessageResponse{ID: d.ID}
+		if d.silence[r.UserID] {
+			res.Silence = 1
+		}
+		return res, nil
+	}
+
+	h["/api/dialog/msg/color"] = func(r *Request) (any, error) {
+		d, err := s.dialog(r)
+		if err != nil {
+			return nil, err
+		}
+		d.color[r.UserID] = r.String("color")
+		return types.ColorMessageResponse{ID: d.ID, Color: d.color[r.UserID]}, nil
+	}
+
+	h["/api/dialog/msg/forward"] = func(r *Request) (any, error) {
+		m, _, err := s.message(r)
+		if err != nil {
+			return nil, err
+		}
+		items := []types.MessageItem{}
+		for _, id := range r.Ints("dialogids") {
+			d, ok := s.dialogs[id]
+			if !ok || !slices.Contains(d.members, r.UserID) {
+				return nil, Errorf("会话不存在或已被删除")
+			}
+			forwardID := m.ID
+			fm := s.newMessage(d, r.UserID, m.Type, m.Msg)
+			fm.ForwardID = &forwardID
+			m.ForwardNum++
+			items = append(items, s.messageView(fm))
+		}
+		return items, nil
+	}
+
+	h["/api/dialog/msg/emoji"] = func(r *Request) (any, error) {
+		m, _, err := s.message(r)
+		if err != nil {
+			return nil, err
+		}
+		symbol := r.String("emoji")
+		var emoji []any
+		found := false
+		for _, e := range m.Emoji {
+			entry, _ := e.(map[string]any)
+			if entry["symbol"] == symbol {
+				found = true
+				ids, _ := entry["userids"].([]int)
+				if i := slices.Index(ids, r.UserID); i >= 0 {
+					ids = slices.Delete(ids, i, i+1)
+				} else {
+					ids = append(ids, r.UserID)
+				}
+				if len(ids) == 0 {
+					continue
+				}
+				entry["userids"] = ids
+			}
+			emoji = append(emoji, e)
+		}
+		if !found {
+			emoji = append(emoji, map[string]any{"symbol": symbol, "userids": []int{r.UserID}})
+		}
+		m.Emoji = append([]any{}, emoji...)
+		return s.messageView(m), nil
+	}
+
+	h["/api/dialog/msg/tag"] = func(r *Request) (any, error) {
+		m, _, err := s.message(r)
+		if err != nil {
+			return nil, err
+		}
+		if m.Tag != 0 {
+			m.Tag = 0
+		} else {
+			m.Tag = r.UserID
+		}
+		return s.messageView(m), nil
+	}
+
+	h["/api/dialog/msg/todo"] = func(r *Request) (any, error) {
+		m, d, err := s.message(r)
+		if err != nil {
+			return nil, err
+		}
+		if m.Todo != 0 {
+			m.Todo = 0
+			s.todos = slices.DeleteFunc(s.todos, func(t *types.DialogTodoItem) bool { return t.MsgID == m.ID })
+		} else {
+			m.Todo = r.UserID
+			now := s.nowString()
+			for _, id := range d.members {
+				s.todos = append(s.todos, &types.DialogTodoItem{ID: s.id(), DialogID: d.ID, MsgID: m.ID, UserID: id, CreatedAt: now, UpdatedAt: now})
+			}
+		}
+		return s.messageView(m), nil
+	}
+
+	h["/api/dialog/msg/todolist"] = func(r *Request) (any, error) {
+		m, _, err := s.message(r)
+		if err != nil {
+			return nil, err
+		}
+		items := []types.DialogTodoItem{}
+		for _, t := range s.todos {
+			if t.MsgID == m.ID {
+				items = append(items, *t)
+			}
+		}
+		return items, nil
+	}
+
+	h["/api/dialog/msg/done"] = func(r *Request) (any, error) {
+		m, _, err := s.message(r)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range s.todos {
+			if t.MsgID == m.ID && t.UserID == r.UserID {
+				now := s.nowString()
+				t.DoneAt, t.UpdatedAt = &now, now
+				return *t, nil
+			}
+		}
+		return nil, Errorf("待办不存在")
+	}
+
+	h["/api/dialog/group/add"] = func(r *Request) (any, error) {
+		members := append([]int{r.UserID}, r.Ints("userids")...)
+		if len(members) < 2 {
+			return nil, Errorf("群成员至少2人")
+		}
+		d := s.newDialog("group", "user", cmp.Or(r.String("chat_name"), "群聊"), r.UserID, members, 0)
+		d.Avatar = r.String("avatar")
+		return s.dialogView(d, r.UserID), nil
+	}
+
+	h["/api/dialog/group/edit"] = func(r *Request) (any, error) {
+		d, err := s.dialog(r)
+		if err != nil {
+			return nil, err
+		}
+		if r.Has("chat_name") {
+			d.Name = r.String("chat_name")
+		}
+		if r.Has("avatar") {
+			d.Avatar = r.String("avatar")
+		}
+		return s.dialogView(d, r.UserID), nil
+	}
+
+	h["/api/dialog/group/adduser"] = func(r *Request) (any, error) {
+		d, err := s.dialog(r)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range r.Ints("userids") {
+			if !slices.Contains(d.members, id) {
+				d.members = append(d.members, id)
+			}
+		}
+		return s.peopleCount(d), nil
+	}
+
+	h["/api/dialog/group/deluser"] = func(r *Request) (any, error) {
+		d, err := s.dialog(r)
+		if err != nil {
+			return nil, err
+		}
+		ids := r.Ints("userids")
+		if len(ids) == 0 || r.String("type") == "exit" {
+			ids = []int{r.UserID}
+		}
+		if d.OwnerID != nil && slices.Contains(ids, *d.OwnerID) {
+			return nil, Errorf("群主不可退出")
+		}
+		d.members = slices.DeleteFunc(d.members, func(id int) bool { return slices.Contains(ids, id) })
+		return types.DelGroupUserResponse(s.peopleCount(d)), nil
+	}
+
+	h["/api/dialog/group/transfer"] = func(r *Request) (any, error) {
+		d, err := s.dialog(r)
+		if err != nil {
+			return nil, err
+		}
+		to := r.Int("userid")
+		if !slices.Contains(d.members, to) {
+			return nil, Errorf("新群主不在群内")
+		}
+		d.OwnerID = &to
+		return s.dialogView(d, r.UserID), nil
+	}
+
+	h["/api/dialog/group/disband"] = func(r *Request) (any, error) {
+		d, err := s.dialog(r)
+		if err != nil {
+			return nil, err
+		}
+		if d.OwnerID == nil || *d.OwnerID != r.UserID {
+			return nil, Errorf("仅限群主操作")
+		}
+		delete(s.dialogs, d.ID)
+		return types.DisbandGroupResponse{Success: true}, nil
+	}
+}
@@ -0,0 +1,471 @@
+package dootasktest
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/xxyijixx/dootask-golang-sdk/types"
+)
+
+// File returns a copy of the file with the given ID
+func (s *Server) File(id int) (types.File, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, ok := s.files[id]
+	if !ok {
+		return types.File{}, false
+	}
+	return *f, true
+}
+
+func (s *Server) canAccess(f *types.File, userID int) bool {
+	if f.UserID == userID {
+		return true
+	}
+	for id := f.ID; id != 0; {
+		if slices.ContainsFunc(s.fileUsers[id], func(u types.FileUser) bool { return u.UserID == userID || u.UserID == 0 }) {
+			return true
+		}
+		parent, ok := s.files[id]
+		if !ok || parent.PID == nil {
+			break
+		}
+		id = *parent.PID
+	}
+	return false
+}
+
+// fileByParam resolves the id parameter, a file ID or a share link code
+func (s *Server) fileByParam(r *Request) (*types.File, error) {
+	v := r.String("id")
+	if id, err := strconv.Atoi(v); err == nil {
+		if f, ok := s.files[id]; ok && s.canAccess(f, r.UserID) {
+			return f, nil
+		}
+		return nil, Errorf("文件不存在或已被删除")
+	}
+	for id, link := range s.fileLinks {
+		if link.Code == v {
+			if f, ok := s.files[id]; ok {
+				return f, nil
+			}
+		}
+	}
+	return nil, Errorf("链接不存在")
+}
+
+func (s *Server) children(pid int) []*types.File {
+	var out []*types.File
+	for _, f := range s.files {
+		if derefInt(f.PID) == pid {
+			out = append(out, f)
+		}
+	}
+	slices.SortFunc(out, func(a, b *types.File) int { return cmp.Compare(a.ID, b.ID) })
+	return out
+}
+
+func derefInt(p *int) int {
+	if p == nil {
+		return 0
+	}
+	return *p
+}
+
+func (s *Server) removeFile(f *types.File) {
+	for _, c := range s.children(f.ID) {
+		s.removeFile(c)
+	}
+	delete(s.files, f.ID)
+	delete(s.contents, f.ID)
+	delete(s.fileLinks, f.ID)
+	delete(s.fileUsers, f.ID)
+}
+
+func (s *Server) copyFile(f *types.File, pid *int, userID int) *types.File {
+	now := s.now()
+	c := *f
+	c.ID = s.id()
+	c.PID = pid
+	cid := f.ID
+	c.CID = &cid
+	c.UserID, c.CreatedID = userID, userID
+	c.CreatedAt, c.UpdatedAt = now, now
+	s.files[c.ID] = &c
+	if contents := s.contents[f.ID]; len(contents) > 0 {
+		last := contents[len(contents)-1]
+		last.ID, last.FID = s.id(), c.ID
+		s.contents[c.ID] = []types.FileContent{last}
+	}
+	for _, child := range s.children(f.ID) {
+		id := c.ID
+		s.copyFile(child, &id, userID)
+	}
+	return &c
+}
+
+func fileList(files []*types.File) []types.File {
+	out := make([]types.File, 0, len(files))
+	for _, f := range files {
+		out = append(out, *f)
+	}
+	return out
+}
+
+func (s *Server) registerFile() {
+	h := s.handlers
+
+	h["/api/file/lists"] = func(r *Request) (any, error) {
+		pid := r.Int("pid")
+		if pid != 0 {
+			if f, ok := s.files[pid]; !ok || f.Type != "folder" || !s.canAccess(f, r.UserID) {
+				return nil, Errorf("目录不存在")
+			}
+		}
+		var items []*types.File
+		for _, f := range s.children(pid) {
+			if s.canAccess(f, r.UserID) {
+				items = append(items, f)
+			}
+		}
+		return fileList(items), nil
+	}
+
+	h["/api/file/one"] = func(r *Request) (any, error) {
+		f, err := s.fileByParam(r)
+		if err != nil {
+			return nil, err
+		}
+		return *f, nil
+	}
+
+	h["/api/file/search"] = func(r *Request) (any, error) {
+		if code := r.String("link"); code != "" {
+			code = code[strings.LastIndexByte(code, '/')+1:]
+			for id, link := range s.fileLinks {
+				if link.Code == code {
+					return fileList([]*types.File{s.files[id]}), nil
+				}
+			}
+			return []types.File{}, nil
+		}
+		key := r.String("key")
+		take := min(cmp.Or(r.Int("take"), 50), 100)
+		var items []*types.File
+		for _, f := range s.files {
+			if s.canAccess(f, r.UserID) && strings.Contains(f.Name, key) {
+				items = append(items, f)
+			}
+		}
+		slices.SortFunc(items, func(a, b *types.File) int { return cmp.Compare(a.ID, b.ID) })
+		return fileList(items[:min(len(items), take)]), nil
+	}
+
+	h["/api/file/add"] = func(r *Request) (any, error) {
+		name := strings.TrimSpace(r.String("name"))
+		if name == "" {
+			return nil, Errorf("名称不能为空")
+		}
+		if id := r.Int("id"); id != 0 {
+			f, ok := s.files[id]
+			if !ok || !s.canAccess(f, r.UserID) {
+				return nil, Errorf("文件不存在或已被删除")
+			}
+			f.Name = name
+			f.UpdatedAt = s.now()
+			return *f, nil
+		}
+
+		var pid *int
+		if v := r.Int("pid"); v != 0 {
+			parent, ok := s.files[v]
+			if !ok || parent.Type != "folder" || !s.canAccess(parent, r.UserID) {
+				return nil, Errorf("目录不存在")
+			}
+			pid = &v
+		}
+		now := s.now()
+		f := &types.File{
+			ID:        s.id(),
+			PID:       pid,
+			Name:      name,
+			Type:      cmp.Or(r.String("type"), "folder"),
+			UserID:    r.UserID,
+			CreatedID: r.UserID,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		s.files[f.ID] = f
+		return *f, nil
+	}
+
+	h["/api/file/copy"] = func(r *Request) (any, error) {
+		f, err := s.fileByParam(r)
+		if err != nil {
+			return nil, err
+		}
+		c := s.copyFile(f, f.PID, r.UserID)
+		c.Name = f.Name + " copy"
+		return *c, nil
+	}
+
+	h["/api/file/move"] = func(r *Request) (any, error) {
+		pid := r.Int("pid")
+		if pid != 0 {
+			if parent, ok := s.files[pid]; !ok || parent.Type != "folder" || !s.canAccess(parent, r.UserID) {
+				return nil, Errorf("目录不存在")
+			}
+		}
+		var moved []*types.File
+		for _, id := range r.Ints("ids") {
+			f, ok := s.files[id]
+			if !ok || !s.canAccess(f, r.UserID) {
+				return nil, Errorf("文件不存在或已被删除")
+			}
+			if id == pid {
+				return nil, Errorf("不能移动到自身")
+			}
+			moved = append(moved, f)
+		}
+		for _, f := range moved {
+			if pid == 0 {
+				f.PID = nil
+			} else {
+				v := pid
+				f.PID = &v
+			}
+			f.UpdatedAt = s.now()
+		}
+		return fileList(moved), nil
+	}
+
+	h["/api/file/remove"] = func(r *Request) (any, error) {
+		var removed []*types.File
+		for _, id := range r.Ints("ids") {
+			f, ok := s.files[id]
+			if !ok || f.UserID != r.UserID {
+				return nil, Errorf("文件不存在或已被删除")
+			}
+			removed = append(removed, f)
+		}
+		for _, f := range removed {
+			s.removeFile(f)
+		}
+		return fileList(removed), nil
+	}
+
+	h["/api/file/content"] = func(r *Request) (any, error) {
+		f, err := s.fileByParam(r)
+		if err != nil {
+			return nil, err
+		}
+		if r.String("only_update_at") == "yes" {
+			return map[string]any{"id": f.ID, "update_at": f.UpdatedAt}, nil
+		}
+		contents := s.contents[f.ID]
+		if id := r.Int("history_id"); id != 0 {
+			for _, c := range contents {
+				if c.ID == id {
+					return map[string]any{"content": c.Content}, nil
+				}
+			}
+			return nil, Errorf("历史数据不存在")
+		}
+		if len(contents) == 0 {
+			return map[string]any{"content": ""}, nil
+		}
+		return map[string]any{"content": contents[len(contents)-1].Content}, nil
+	}
+
+	h["/api/file/content/save"] = func(r *Request) (any, error) {
+		f, err := s.fileByParam(r)
+		if err != nil {
+			return nil, err
+		}
+		if f.Type == "folder" {
+			return nil, Errorf("文件夹不能保存内容")
+		}
+		content := r.String("content")
+		size := int64(len(content))
+		c := types.FileContent{
+			ID:        s.id(),
+			FID:       f.ID,
+			Content:   content,
+			Size:      size,
+			UserID:    r.UserID,
+			CreatedAt: s.now(),
+		}
+		s.contents[f.ID] = append(s.contents[f.ID], c)
+		f.Size = &size
+		f.UpdatedAt = c.CreatedAt
+		return c, nil
+	}
+
+	h["/api/file/content/upload"] = func(r *Request) (any, error) {
+		uploads := r.Files("files")
+		if len(uploads) == 0 {
+			return nil, Errorf("请选择上传文件")
+		}
+		var pid *int
+		if v := r.Int("pid"); v != 0 {
+			parent, ok := s.files[v]
+			if !ok || parent.Type != "folder" || !s.canAccess(parent, r.UserID) {
+				return nil, Errorf("目录不存在")
+			}
+			pid = &v
+		}
+		var added []*types.File
+		for _, upload := range uploads {
+			content, err := readUpload(upload)
+			if err != nil {
+				return nil, err
+			}
+			var f *types.File
+			for _, c := range s.children(derefInt(pid)) {
+				if c.Name == upload.Filename && c.Type != "folder" {
+					f = c
+				}
+			}
+			if f != nil && r.Int("cover") != 1 {
+				return nil, Errorf("文件 %s 已存在", upload.Filename)
+			}
+			now := s.now()
+			if f == nil {
+				f = &types.File{
+					ID:        s.id(),
+					PID:       pid,
+					Name:      upload.Filename,
+					Type:      "file",
+					UserID:    r.UserID,
+					CreatedID: r.UserID,
+					CreatedAt: now,
+				}
+				s.files[f.ID] = f
+			}
+			size := int64(len(content))
+			s.contents[f.ID] = append(s.contents[f.ID], types.FileContent{
+				ID:        s.id(),
+				FID:       f.ID,
+				Content:   content,
+				Size:      size,
+				UserID:    r.UserID,
+				CreatedAt: now,
+			})
+			f.Size = &size
+			f.UpdatedAt = now
+			added = append(added, f)
+		}
+		return fileList(added), nil
+	}
+
+	h["/api/file/content/history"] = func(r *Request) (any, error) {
+		f, err := s.fileByParam(r)
+		if err != nil {
+			return nil, err
+		}
+		contents := s.contents[f.ID]
+		items := make([]types.FileHistory, 0, len(contents))
+		for i := len(contents) - 1; i >= 0; i-- {
+			c := contents[i]
+			items = append(items, types.FileHistory{ID: c.ID, Size: c.Size, UserID: c.UserID, CreatedAt: c.CreatedAt})
+		}
+		return paginate(r, items, 20), nil
+	}
+
+	h["/api/file/content/restore"] = func(r *Request) (any, error) {
+		f, err := s.fileByParam(r)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range s.contents[f.ID] {
+			if c.ID == r.Int("history_id") {
+				c.ID, c.UserID, c.CreatedAt = s.id(), r.UserID, s.now()
+				s.contents[f.ID] = append(s.contents[f.ID], c)
+				f.UpdatedAt = c.CreatedAt
+				return nil, nil
+			}
+		}
+		return nil, Errorf("历史数据不存在")
+	}
+
+	h["/api/file/share"] = func(r *Request) (any, error) {
+		f, err := s.fileByParam(r)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"id": f.ID, "list": slices.Concat([]types.FileUser{}, s.fileUsers[f.ID])}, nil
+	}
+
+	h["/api/file/share/update"] = func(r *Request) (any, error) {
+		f, err := s.fileByParam(r)
+		if err != nil {
+			return nil, err
+		}
+		if f.UserID != r.UserID {
+			return nil, Errorf("仅限所有者操作")
+		}
+		permission := r.Int("permission")
+		ids := r.Ints("userids")
+		users := slices.DeleteFunc(s.fileUsers[f.ID], func(u types.FileUser) bool { return slices.Contains(ids, u.UserID) })
+		if permission != -1 {
+			for _, id := range ids {
+				users = append(users, types.FileUser{FileID: f.ID, UserID: id, Permission: permission})
+			}
+		}
+		s.fileUsers[f.ID] = users
+		share := 0
+		if len(users) > 0 {
+			share = 1
+		}
+		f.Share = &share
+		return *f, nil
+	}
+
+	h["/api/file/share/out"] = func(r *Request) (any, error) {
+		f, err := s.fileByParam(r)
+		if err != nil {
+			return nil, err
+		}
+		if f.UserID == r.UserID {
+			return nil, Errorf("不能退出自己的共享")
+		}
+		s.fileUsers[f.ID] = slices.DeleteFunc(s.fileUsers[f.ID], func(u types.FileUser) bool { return u.UserID == r.UserID })
+		return nil, nil
+	}
+
+	h["/api/file/link"] = func(r *Request) (any, error) {
+		f, err := s.fileByParam(r)
+		if err != nil {
+			return nil, err
+		}
+		link, ok := s.fileLinks[f.ID]
+		if !ok || r.String("refresh") == "yes" {
+			code := fmt.Sprintf("fl%d%d", f.ID, s.id())
+			link = &types.FileLink{Code: code, URL: s.URL + "/single/file/" + code}
+			s.fileLinks[f.ID] = link
+		}
+		switch r.String("guest_access") {
+		case "yes":
+			link.GuestAccess = 1
+		case "no":
+			link.GuestAccess = 0
+		}
+		return *link, nil
+	}
+}
+
+// readUpload returns the content of an uploaded file
+func readUpload(upload *multipart.FileHeader) (string, error) {
+	f, err := upload.Open()
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	b, err := io.ReadAll(f)
+	return string(b), err
+}
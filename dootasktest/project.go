@@ -0,0 +1,677 @@
+package dootasktest
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/xxyijixx/dootask-golang-sdk/types"
+)
+
+// Project returns a copy of the project with the given ID
+func (s *Server) Project(id int) (types.ProjectDetail, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.projects[id]
+	if !ok {
+		return types.ProjectDetail{}, false
+	}
+	return *p, true
+}
+
+// Task returns a copy of the task with the given ID
+func (s *Server) Task(id int) (types.TaskInfo, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.tasks[id]
+	if !ok {
+		return types.TaskInfo{}, false
+	}
+	return *t, true
+}
+
+func (s *Server) project(r *Request) (*types.ProjectDetail, error) {
+	p, ok := s.projects[r.Int("project_id")]
+	if !ok || !isMember(p, r.UserID) {
+		return nil, Errorf("项目不存在或不在成员列表内")
+	}
+	return p, nil
+}
+
+func isMember(p *types.ProjectDetail, userID int) bool {
+	return slices.ContainsFunc(p.Members, func(m types.ProjectMember) bool { return m.UserID == userID })
+}
+
+func (s *Server) task(id, userID int) (*types.TaskInfo, error) {
+	t, ok := s.tasks[id]
+	if !ok {
+		return nil, Errorf("任务不存在")
+	}
+	if p, ok := s.projects[t.ProjectID]; !ok || !isMember(p, userID) {
+		return nil, Errorf("项目不存在或不在成员列表内")
+	}
+	return t, nil
+}
+
+func (s *Server) column(id int) (*types.ProjectColumn, *types.ProjectDetail) {
+	for _, p := range s.projects {
+		for i := range p.Columns {
+			if p.Columns[i].ID == id {
+				return &p.Columns[i], p
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (s *Server) addMember(p *types.ProjectDetail, userID, owner int) {
+	if isMember(p, userID) {
+		return
+	}
+	p.Members = append(p.Members, types.ProjectMember{
+		ID:        s.id(),
+		ProjectID: p.ID,
+		UserID:    userID,
+		Owner:     owner,
+		CreatedAt: s.now(),
+		User:      s.userInfo(userID),
+	})
+}
+
+func (s *Server) syncOwners(p *types.ProjectDetail) {
+	p.Owner = p.Owner[:0]
+	for _, m := range p.Members {
+		if m.Owner == 1 {
+			p.Owner = append(p.Owner, m.UserID)
+		}
+	}
+	p.OwnerUser = s.usersInfo(p.Owner)
+}
+
+func (s *Server) log(projectID, taskID, userID int, detail string) {
+	s.logs = append(s.logs, types.LogInfo{
+		ID:        s.id(),
+		ProjectID: projectID,
+		TaskID:    taskID,
+		Content:   detail,
+		UserID:    userID,
+		User:      s.userInfo(userID),
+		CreatedAt: s.now(),
+	})
+}
+
+func projectInfo(p *types.ProjectDetail) types.ProjectInfo {
+	return types.ProjectInfo{
+		ID:            p.ID,
+		Name:          p.Name,
+		Desc:          p.Desc,
+		DialogID:      p.DialogID,
+		Owner:         p.Owner,
+		OwnerUser:     p.OwnerUser,
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
+		ArchivedAt:    p.ArchivedAt,
+		TopAt:         p.TopAt,
+		Personal:      p.Personal,
+		Flow:          p.Flow,
+		ArchiveDays:   p.ArchiveDays,
+		ArchiveMethod: p.ArchiveMethod,
+	}
+}
+
+func (s *Server) taskView(t *types.TaskInfo) types.TaskInfo {
+	view := *t
+	view.Content = ""
+	view.OwnerUser = s.usersInfo(t.Owner)
+	view.AssistUser = s.usersInfo(t.Assist)
+	view.SubTasks = nil
+	for _, sub := range sortedTasks(s.tasks) {
+		if sub.ParentID == t.ID {
+			view.SubTasks = append(view.SubTasks, *sub)
+		}
+	}
+	return view
+}
+
+func sortedTasks(tasks map[int]*types.TaskInfo) []*types.TaskInfo {
+	out := make([]*types.TaskInfo, 0, len(tasks))
+	for _, t := range tasks {
+		out = append(out, t)
+	}
+	slices.SortFunc(out, func(a, b *types.TaskInfo) int {
+		return cmp.Or(cmp.Compare(a.Sort, b.Sort), cmp.Compare(a.ID, b.ID))
+	})
+	return out
+}
+
+func (s *Server) parseTime(v string) *types.DateTime {
+	if v == "" {
+		return nil
+	}
+	t, err := time.ParseInLocation(time.DateTime, v, time.Local)
+	if err != nil {
+		return nil
+	}
+	return &types.DateTime{Time: t}
+}
+
+func (s *Server) registerProject() {
+	h := s.handlers
+
+	h["/api/project/lists"] = func(r *Request) (any, error) {
+		archived := cmp.Or(r.String("archived"), "no")
+		search := r.String("search")
+		var items []types.ProjectInfo
+		for _, p := range sortedProjects(s.projects) {
+			if !isMember(p, r.UserID) {
+				continue
+			}
+			if (archived == "no" && p.ArchivedAt != nil) || (archived == "yes" && p.ArchivedAt == nil) {
+				continue
+			}
+			if search != "" && !strings.Contains(p.Name, search) {
+				continue
+			}
+			items = append(items, projectInfo(p))
+		}
+		return paginate(r, items, 50), nil
+	}
+
+	h["/api/project/one"] = func(r *Request) (any, error) {
+		p, err := s.project(r)
+		if err != nil {
+			return nil, err
+		}
+		return types.ProjectOneResponse{Data: *p}, nil
+	}
+
+	h["/api/project/add"] = func(r *Request) (any, error) {
+		name := strings.TrimSpace(r.String("name"))
+		if name == "" {
+			return nil, Errorf("项目名称不能为空")
+		}
+		now := s.now()
+		p := &types.ProjectDetail{
+			ID:        s.id(),
+			Name:      name,
+			Desc:      r.String("desc"),
+			Flow:      cmp.Or(r.String("flow"), "close"),
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if r.String("personal") == "1" || r.String("personal") == "true" {
+			p.Personal = 1
+		}
+		p.DialogID = s.newDialog("group", "project", name, r.UserID, []int{r.UserID}, p.ID).ID
+		s.addMember(p, r.UserID, 1)
+		s.syncOwners(p)
+		columns := r.Strings("columns")
+		if len(columns) == 0 {
+			columns = []string{"Backlog", "In progress", "Done"}
+		}
+		for i, name := range columns {
+			p.Columns = append(p.Columns, types.ProjectColumn{ID: s.id(), ProjectID: p.ID, Name: name, Sort: i, CreatedAt: now})
+		}
+		s.projects[p.ID] = p
+		s.log(p.ID, 0, r.UserID, "创建项目")
+		return types.ProjectAddResponse{Data: *p}, nil
+	}
+
+	h["/api/project/update"] = func(r *Request) (any, error) {
+		p, err := s.project(r)
+		if err != nil {
+			return nil, err
+		}
+		if r.Has("name") {
+			p.Name = r.String("name")
+		}
+		if r.Has("desc") {
+			p.Desc = r.String("desc")
+		}
+		if r.Has("archive_days") {
+			p.ArchiveDays = r.Int("archive_days")
+		}
+		if r.Has("archive_method") {
+			p.ArchiveMethod = r.String("archive_method")
+		}
+		p.UpdatedAt = s.now()
+		s.log(p.ID, 0, r.UserID, "修改项目信息")
+		return types.ProjectUpdateResponse{Data: *p}, nil
+	}
+
+	h["/api/project/transfer"] = func(r *Request) (any, error) {
+		p, err := s.project(r)
+		if err != nil {
+			return nil, err
+		}
+		to := r.Int("userid")
+		s.addMember(p, to, 0)
+		for i := range p.Members {
+			p.Members[i].Owner = 0
+			if p.Members[i].UserID == to {
+				p.Members[i].Owner = 1
+			}
+		}
+		s.syncOwners(p)
+		return types.ProjectTransferResponse{Data: "移交成功"}, nil
+	}
+
+	h["/api/project/exit"] = func(r *Request) (any, error) {
+		p, err := s.project(r)
+		if err != nil {
+			return nil, err
+		}
+		if slices.Contains(p.Owner, r.UserID) {
+			return nil, Errorf("项目负责人无法退出项目")
+		}
+		p.Members = slices.DeleteFunc(p.Members, func(m types.ProjectMember) bool { return m.UserID == r.UserID })
+		return types.ProjectExitResponse{Data: "退出成功"}, nil
+	}
+
+	h["/api/project/archived"] = func(r *Request) (any, error) {
+		p, err := s.project(r)
+		if err != nil {
+			return nil, err
+		}
+		switch r.String("type") {
+		case "archive":
+			now := s.now()
+			p.ArchivedAt = &now
+		case "reduction":
+			p.ArchivedAt = nil
+		default:
+			return nil, Errorf("参数错误")
+		}
+		return types.ProjectArchivedResponse{Data: "操作成功"}, nil
+	}
+
+	h["/api/project/remove"] = func(r *Request) (any, error) {
+		p, err := s.project(r)
+		if err != nil {
+			return nil, err
+		}
+		delete(s.projects, p.ID)
+		for id, t := range s.tasks {
+			if t.ProjectID == p.ID {
+				delete(s.tasks, id)
+			}
+		}
+		return types.ProjectRemoveResponse{Data: "删除成功"}, nil
+	}
+
+	h["/api/project/top"] = func(r *Request) (any, error) {
+		p, err := s.project(r)
+		if err != nil {
+			return nil, err
+		}
+		if r.String("type") == "delete" || p.TopAt != nil {
+			p.TopAt = nil
+		} else {
+			now := s.now()
+			p.TopAt = &now
+		}
+		return types.ProjectTopResponse{Data: "操作成功"}, nil
+	}
+
+	h["/api/project/user"] = func(r *Request) (any, error) {
+		p, err := s.project(r)
+		if err != nil {
+			return nil, err
+		}
+		ids := r.Ints("userids")
+		switch r.String("type") {
+		case "delete":
+			p.Members = slices.DeleteFunc(p.Members, func(m types.ProjectMember) bool {
+				return slices.Contains(ids, m.UserID) && m.Owner == 0
+			})
+		case "owner":
+			for _, id := range ids {
+				s.addMember(p, id, 1)
+			}
+			for i := range p.Members {
+				if slices.Contains(ids, p.Members[i].UserID) {
+					p.Members[i].Owner = 1
+				}
+			}
+		default:
+			for _, id := range ids {
+				s.addMember(p, id, 0)
+			}
+		}
+		s.syncOwners(p)
+		return types.ProjectUserResponse{Data: "修改成功"}, nil
+	}
+
+	h["/api/project/column/lists"] = func(r *Request) (any, error) {
+		p, err := s.project(r)
+		if err != nil {
+			return nil, err
+		}
+		return types.ProjectColumnListsResponse{Data: p.Columns}, nil
+	}
+
+	h["/api/project/column/add"] = func(r *Request) (any, error) {
+		p, err := s.project(r)
+		if err != nil {
+			return nil, err
+		}
+		c := types.ProjectColumn{
+			ID:        s.id(),
+			ProjectID: p.ID,
+			Name:      r.String("name"),
+			Color:     r.String("color"),
+			Sort:      len(p.Columns),
+			CreatedAt: s.now(),
+		}
+		p.Columns = append(p.Columns, c)
+		return types.ProjectColumnAddResponse{Data: c}, nil
+	}
+
+	h["/api/project/column/update"] = func(r *Request) (any, error) {
+		c, p := s.column(r.Int("column_id"))
+		if c == nil || !isMember(p, r.UserID) {
+			return nil, Errorf("列表不存在")
+		}
+		if r.Has("name") {
+			c.Name = r.String("name")
+		}
+		if r.Has("color") {
+			c.Color = r.String("color")
+		}
+		return types.ProjectColumnUpdateResponse{Data: *c}, nil
+	}
+
+	h["/api/project/column/remove"] = func(r *Request) (any, error) {
+		c, p := s.column(r.Int("column_id"))
+		if c == nil || !isMember(p, r.UserID) {
+			return nil, Errorf("列表不存在")
+		}
+		id := c.ID
+		p.Columns = slices.DeleteFunc(p.Columns, func(c types.ProjectColumn) bool { return c.ID == id })
+		for tid, t := range s.tasks {
+			if t.ColumnID == id {
+				delete(s.tasks, tid)
+			}
+		}
+		return types.ProjectColumnRemoveResponse{Data: "删除成功"}, nil
+	}
+
+	h["/api/project/column/one"] = func(r *Request) (any, error) {
+		c, p := s.column(r.Int("column_id"))
+		if c == nil || !isMember(p, r.UserID) {
+			return nil, Errorf("列表不存在")
+		}
+		return types.ProjectColumnOneResponse{Data: *c}, nil
+	}
+
+	h["/api/project/task/lists"] = func(r *Request) (any, error) {
+		items := s.filterTasks(r)
+		return paginate(r, items, 100), nil
+	}
+
+	h["/api/project/task/easylists"] = func(r *Request) (any, error) {
+		return types.ProjectTaskEasyListsResponse{Data: s.filterTasks(r)}, nil
+	}
+
+	h["/api/project/task/one"] = func(r *Request) (any, error) {
+		t, err := s.task(r.Int("task_id"), r.UserID)
+		if err != nil {
+			return nil, err
+		}
+		return types.ProjectTaskOneResponse{Data: s.taskView(t)}, nil
+	}
+
+	h["/api/project/task/content"] = func(r *Request) (any, error) {
+		t, err := s.task(r.Int("task_id"), r.UserID)
+		if err != nil {
+			return nil, err
+		}
+		return types.ProjectTaskContentResponse{Data: types.TaskContent{Content: t.Content}}, nil
+	}
+
+	h["/api/project/task/add"] = func(r *Request) (any, error) {
+		p, err := s.project(r)
+		if err != nil {
+			return nil, err
+		}
+		name := strings.TrimSpace(r.String("name"))
+		if name == "" {
+			return nil, Errorf("任务描述不能为空")
+		}
+		columnID := r.Int("column_id")
+		if columnID == 0 && len(p.Columns) > 0 {
+			columnID = p.Columns[0].ID
+		}
+		if c, cp := s.column(columnID); c == nil || cp.ID != p.ID {
+			return nil, Errorf("列表不存在")
+		}
+		owner := r.Ints("owner")
+		if len(owner) == 0 {
+			owner = []int{r.UserID}
+		}
+		now := s.now()
+		t := &types.TaskInfo{
+			ID:        s.id(),
+			ProjectID: p.ID,
+			ColumnID:  columnID,
+			Name:      name,
+			Content:   r.String("content"),
+			Owner:     owner,
+			Assist:    r.Ints("assist"),
+			StartAt:   s.parseTime(r.String("start_at")),
+			EndAt:     s.parseTime(r.String("end_at")),
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		t.Sort = t.ID
+		s.tasks[t.ID] = t
+		s.log(p.ID, t.ID, r.UserID, "创建任务")
+		return types.ProjectTaskAddResponse{Data: s.taskView(t)}, nil
+	}
+
+	h["/api/project/task/addsub"] = func(r *Request) (any, error) {
+		parent, err := s.task(r.Int("task_id"), r.UserID)
+		if err != nil {
+			return nil, err
+		}
+		now := s.now()
+		t := &types.TaskInfo{
+			ID:        s.id(),
+			ProjectID: parent.ProjectID,
+			ColumnID:  parent.ColumnID,
+			ParentID:  parent.ID,
+			Name:      r.String("name"),
+			Owner:     []int{r.UserID},
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		t.Sort = t.ID
+		s.tasks[t.ID] = t
+		return types.ProjectTaskAddSubResponse{Data: s.taskView(t)}, nil
+	}
+
+	h["/api/project/task/update"] = func(r *Request) (any, error) {
+		t, err := s.task(r.Int("task_id"), r.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if r.Has("name") {
+			t.Name = r.String("name")
+		}
+		if r.Has("content") {
+			t.Content = r.String("content")
+		}
+		if id := r.Int("column_id"); id != 0 {
+			if c, p := s.column(id); c == nil || p.ID != t.ProjectID {
+				return nil, Errorf("列表不存在")
+			}
+			t.ColumnID = id
+		}
+		if r.Has("owner") || r.Has("owner[]") {
+			t.Owner = r.Ints("owner")
+		}
+		if r.Has("assist") || r.Has("assist[]") {
+			t.Assist = r.Ints("assist")
+		}
+		if r.Has("start_at") {
+			t.StartAt = s.parseTime(r.String("start_at"))
+		}
+		if r.Has("end_at") {
+			t.EndAt = s.parseTime(r.String("end_at"))
+		}
+		if r.Has("complete_at") {
+			switch v := r.String("complete_at"); v {
+			case "", "0", "false":
+				t.CompleteAt = nil
+			default:
+				now := s.now()
+				t.CompleteAt = cmp.Or(s.parseTime(v), &now)
+			}
+		}
+		t.UpdatedAt = s.now()
+		s.log(t.ProjectID, t.ID, r.UserID, "修改任务")
+		return types.ProjectTaskUpdateResponse{Data: s.taskView(t)}, nil
+	}
+
+	h["/api/project/task/dialog"] = func(r *Request) (any, error) {
+		t, err := s.task(r.Int("task_id"), r.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if t.DialogID == 0 {
+			members := slices.Concat(t.Owner, t.Assist)
+			t.DialogID = s.newDialog("group", "task", t.Name, r.UserID, members, t.ID).ID
+		}
+		return types.ProjectTaskDialogResponse{
+			ID:         t.ID,
+			DialogID:   t.DialogID,
+			DialogData: s.dialogView(s.dialogs[t.DialogID], r.UserID),
+		}, nil
+	}
+
+	h["/api/project/task/archived"] = func(r *Request) (any, error) {
+		t, err := s.task(r.Int("task_id"), r.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if r.String("type") == "reduction" {
+			t.ArchivedAt = nil
+		} else {
+			now := s.now()
+			t.ArchivedAt = &now
+		}
+		return types.ProjectTaskArchivedResponse{Data: "操作成功"}, nil
+	}
+
+	h["/api/project/task/remove"] = func(r *Request) (any, error) {
+		t, err := s.task(r.Int("task_id"), r.UserID)
+		if err != nil {
+			return nil, err
+		}
+		for id, sub := range s.tasks {
+			if sub.ParentID == t.ID {
+				delete(s.tasks, id)
+			}
+		}
+		delete(s.tasks, t.ID)
+		s.log(t.ProjectID, t.ID, r.UserID, "删除任务")
+		return types.ProjectTaskRemoveResponse{Data: "删除成功"}, nil
+	}
+
+	h["/api/project/task/move"] = func(r *Request) (any, error) {
+		t, err := s.task(r.Int("task_id"), r.UserID)
+		if err != nil {
+			return nil, err
+		}
+		c, p := s.column(r.Int("column_id"))
+		if c == nil || p.ID != r.Int("project_id") || !isMember(p, r.UserID) {
+			return nil, Errorf("列表不存在")
+		}
+		t.ProjectID, t.ColumnID = p.ID, c.ID
+		for _, sub := range s.tasks {
+			if sub.ParentID == t.ID {
+				sub.ProjectID, sub.ColumnID = p.ID, c.ID
+			}
+		}
+		return types.ProjectTaskMoveResponse{Data: "移动成功"}, nil
+	}
+
+	h["/api/project/sort"] = func(r *Request) (any, error) {
+		if _, err := s.project(r); err != nil {
+			return nil, err
+		}
+		for i, id := range r.Ints("task_ids") {
+			if t, ok := s.tasks[id]; ok && t.ProjectID == r.Int("project_id") {
+				t.Sort = i
+			}
+		}
+		return types.ProjectSortResponse{Data: "调整成功"}, nil
+	}
+
+	h["/api/project/log/lists"] = func(r *Request) (any, error) {
+		var items []types.LogInfo
+		for i := len(s.logs) - 1; i >= 0; i-- {
+			l := s.logs[i]
+			if p, ok := s.projects[l.ProjectID]; ok && !isMember(p, r.UserID) {
+				continue
+			}
+			if id := r.Int("project_id"); id != 0 && l.ProjectID != id {
+				continue
+			}
+			if id := r.Int("task_id"); id != 0 && l.TaskID != id {
+				continue
+			}
+			items = append(items, l)
+		}
+		return paginate(r, items, 20), nil
+	}
+}
+
+// filterTasks returns the top level tasks visible to the user matching the
+// project_id, column_id, parent_id, status and search parameters
+func (s *Server) filterTasks(r *Request) []types.TaskInfo {
+	parentID := r.Int("parent_id")
+	status := r.String("status")
+	search := r.String("search")
+
+	var items []types.TaskInfo
+	for _, t := range sortedTasks(s.tasks) {
+		p, ok := s.projects[t.ProjectID]
+		if !ok || !isMember(p, r.UserID) || t.ParentID != parentID || t.ArchivedAt != nil {
+			continue
+		}
+		if id := r.Int("project_id"); id != 0 && t.ProjectID != id {
+			continue
+		}
+		if id := r.Int("column_id"); id != 0 && t.ColumnID != id {
+			continue
+		}
+		if (status == "completed" && t.CompleteAt == nil) || (status == "uncompleted" && t.CompleteAt != nil) {
+			continue
+		}
+		if search != "" && !strings.Contains(t.Name, search) {
+			continue
+		}
+		items = append(items, s.taskView(t))
+	}
+	return items
+}
+
+func sortedProjects(projects map[int]*types.ProjectDetail) []*types.ProjectDetail {
+	out := make([]*types.ProjectDetail, 0, len(projects))
+	for _, p := range projects {
+		out = append(out, p)
+	}
+	slices.SortFunc(out, func(a, b *types.ProjectDetail) int {
+		// Pinned projects first, then newest first
+		if (a.TopAt != nil) != (b.TopAt != nil) {
+			if a.TopAt != nil {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(b.ID, a.ID)
+	})
+	return out
+}
@@ -0,0 +1,301 @@
+package dootasktest
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/xxyijixx/dootask-golang-sdk/types"
+)
+
+type report struct {
+	types.ReportDetail
+	reads map[int]*types.DateTime // recipient -> read time, nil while unread
+}
+
+// Report returns the report with the given ID as seen by its sender
+func (s *Server) Report(id int) (types.ReportDetail, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rp, ok := s.reports[id]
+	if !ok {
+		return types.ReportDetail{}, false
+	}
+	return s.reportView(rp, rp.UserID), true
+}
+
+func (s *Server) reportView(rp *report, userID int) types.ReportDetail {
+	d := rp.ReportDetail
+	d.User = s.userInfo(d.UserID)
+	d.Recipient = []types.User{}
+	d.ReadUsers = []types.ReadUser{}
+	d.UnreadUsers = []types.UnreadUser{}
+	for _, id := range slices.Sorted(maps.Keys(rp.reads)) {
+		u := s.userInfo(id)
+		d.Recipient = append(d.Recipient, u)
+		if at := rp.reads[id]; at != nil {
+			d.ReadUsers = append(d.ReadUsers, types.ReadUser{UserID: id, ReadAt: *at, User: u})
+		} else {
+			d.UnreadUsers = append(d.UnreadUsers, types.UnreadUser{UserID: id, User: u})
+		}
+	}
+	if at, ok := rp.reads[userID]; ok {
+		d.ReadAt = at
+		if at != nil {
+			d.ReadStatus = 1
+		}
+	}
+	return d
+}
+
+func (s *Server) reportInfo(rp *report, userID int) types.ReportInfo {
+	d := s.reportView(rp, userID)
+	return types.ReportInfo{
+		ID:          d.ID,
+		UserID:      d.UserID,
+		Type:        d.Type,
+		Title:       d.Title,
+		Content:     d.Content,
+		SendAt:      d.SendAt,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+		User:        d.User,
+		Recipient:   d.Recipient,
+		ReadStatus:  d.ReadStatus,
+		ReadAt:      d.ReadAt,
+		UnreadCount: len(d.UnreadUsers),
+	}
+}
+
+// reportList returns the reports matching keep, newest first
+func (s *Server) reportList(r *Request, keep func(rp *report) bool) types.Page[types.ReportInfo] {
+	typ, search := r.String("type"), r.String("search")
+	var matched []*report
+	for _, rp := range s.reports {
+		if typ != "" && rp.Type != typ {
+			continue
+		}
+		if search != "" && !strings.Contains(rp.Title, search) && !strings.Contains(s.userInfo(rp.UserID).Nickname, search) {
+			continue
+		}
+		if keep(rp) {
+			matched = append(matched, rp)
+		}
+	}
+	slices.SortFunc(matched, func(a, b *report) int { return cmp.Compare(b.ID, a.ID) })
+
+	items := make([]types.ReportInfo, 0, len(matched))
+	for _, rp := range matched {
+		items = append(items, s.reportInfo(rp, r.UserID))
+	}
+	return paginate(r, items, 20)
+}
+
+func (s *Server) readableReport(r *Request) (*report, error) {
+	rp, ok := s.reports[r.Int("report_id")]
+	if !ok {
+		return nil, Errorf("汇报不存在")
+	}
+	if _, recipient := rp.reads[r.UserID]; rp.UserID != r.UserID && !recipient {
+		return nil, Errorf("没有权限查看该汇报")
+	}
+	return rp, nil
+}
+
+// lastReport returns the newest report of typ sent by userID, any type when
+// typ is empty
+func (s *Server) lastReport(userID int, typ string) *report {
+	var last *report
+	for _, rp := range s.reports {
+		if rp.UserID == userID && (typ == "" || rp.Type == typ) && (last == nil || rp.ID > last.ID) {
+			last = rp
+		}
+	}
+	return last
+}
+
+func validReportType(typ string) bool {
+	return typ == "day" || typ == "week" || typ == "month"
+}
+
+func (s *Server) registerReport() {
+	h := s.handlers
+
+	h["/api/report/my"] = func(r *Request) (any, error) {
+		return s.reportList(r, func(rp *report) bool { return rp.UserID == r.UserID }), nil
+	}
+
+	h["/api/report/receive"] = func(r *Request) (any, error) {
+		return s.reportList(r, func(rp *report) bool {
+			_, ok := rp.reads[r.UserID]
+			return ok
+		}), nil
+	}
+
+	h["/api/report/detail"] = func(r *Request) (any, error) {
+		rp, err := s.readableReport(r)
+		if err != nil {
+			return nil, err
+		}
+		if at, ok := rp.reads[r.UserID]; ok && at == nil {
+			now := s.now()
+			rp.reads[r.UserID] = &now
+		}
+		return map[string]any{"data": s.reportView(rp, r.UserID)}, nil
+	}
+
+	h["/api/report/store"] = func(r *Request) (any, error) {
+		typ, title := r.String("type"), strings.TrimSpace(r.String("title"))
+		if !validReportType(typ) {
+			return nil, Errorf("汇报类型错误")
+		}
+		if title == "" {
+			return nil, Errorf("请填写标题")
+		}
+		recipients := r.Ints("recipient")
+		if len(recipients) == 0 {
+			return nil, Errorf("请选择接收人")
+		}
+
+		now := s.now()
+		rp, ok := s.reports[r.Int("report_id")]
+		if ok && rp.UserID != r.UserID {
+			return nil, Errorf("只能修改自己的汇报")
+		}
+		if !ok {
+			rp = &report{ReportDetail: types.ReportDetail{ID: s.id(), UserID: r.UserID, CreatedAt: now}}
+			s.reports[rp.ID] = rp
+		}
+		rp.Type, rp.Title, rp.Content = typ, title, r.String("content")
+		rp.UpdatedAt = now
+		rp.SendAt = now
+		if v := r.String("send_at"); v != "" {
+			t, err := time.ParseInLocation(time.DateTime, v, time.Local)
+			if err != nil {
+				return nil, Errorf("发送时间格式错误")
+			}
+			rp.SendAt = types.DateTime{Time: t}
+		}
+		rp.reads = make(map[int]*types.DateTime, len(recipients))
+		for _, id := range recipients {
+			rp.reads[id] = nil
+		}
+		return map[string]any{"data": s.reportView(rp, r.UserID)}, nil
+	}
+
+	h["/api/report/template"] = func(r *Request) (any, error) {
+		typ := r.String("type")
+		if !validReportType(typ) {
+			return nil, Errorf("汇报类型错误")
+		}
+		day := s.Now()
+		if v := r.String("time"); v != "" {
+			t, err := time.ParseInLocation(time.DateOnly, v, time.Local)
+			if err != nil {
+				return nil, Errorf("时间格式错误")
+			}
+			day = t
+		}
+		start, end := day, day
+		switch typ {
+		case "week":
+			start = day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+			end = start.AddDate(0, 0, 6)
+		case "month":
+			start = day.AddDate(0, 0, 1-day.Day())
+			end = start.AddDate(0, 1, -1)
+		}
+
+		tasks := []types.TaskInfo{}
+		for _, t := range sortedTasks(s.tasks) {
+			if t.CompleteAt != nil && slices.Contains(t.Owner, r.UserID) {
+				tasks = append(tasks, s.taskView(t))
+			}
+		}
+		last := []types.User{}
+		if rp := s.lastReport(r.UserID, typ); rp != nil {
+			last = s.reportView(rp, r.UserID).Recipient
+		}
+
+		user := s.userInfo(r.UserID)
+		return map[string]any{"data": types.ReportTemplate{
+			Type:      typ,
+			Title:     fmt.Sprintf("%s的%s[%s]", user.Nickname, reportTypeName(typ), start.Format(time.DateOnly)),
+			Content:   "",
+			Tasks:     tasks,
+			TimeRange: types.TimeRange{Start: start.Format(time.DateOnly), End: end.Format(time.DateOnly)},
+			Recipient: last,
+		}}, nil
+	}
+
+	h["/api/report/mark"] = func(r *Request) (any, error) {
+		rp, err := s.readableReport(r)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := rp.reads[r.UserID]; !ok {
+			return nil, Errorf("只有接收人可以标记")
+		}
+		switch r.String("type") {
+		case "read":
+			now := s.now()
+			rp.reads[r.UserID] = &now
+		case "unread":
+			rp.reads[r.UserID] = nil
+		default:
+			return nil, Errorf("操作类型错误")
+		}
+		return nil, nil
+	}
+
+	h["/api/report/read"] = func(r *Request) (any, error) {
+		now := s.now()
+		for _, id := range r.Ints("report_ids") {
+			if rp, ok := s.reports[id]; ok {
+				if at, ok := rp.reads[r.UserID]; ok && at == nil {
+					rp.reads[r.UserID] = &now
+				}
+			}
+		}
+		return nil, nil
+	}
+
+	h["/api/report/unread"] = func(r *Request) (any, error) {
+		typ := r.String("type")
+		info := types.UnreadInfo{ByType: map[string]int{}}
+		for _, rp := range s.reports {
+			if at, ok := rp.reads[r.UserID]; !ok || at != nil || (typ != "" && rp.Type != typ) {
+				continue
+			}
+			info.Total++
+			info.ByType[rp.Type]++
+			if info.LastTime == nil || rp.SendAt.After(info.LastTime.Time) {
+				sendAt := rp.SendAt
+				info.LastTime = &sendAt
+			}
+		}
+		info.Day, info.Week, info.Month = info.ByType["day"], info.ByType["week"], info.ByType["month"]
+		return map[string]any{"data": info}, nil
+	}
+
+	h["/api/report/last_submitter"] = func(r *Request) (any, error) {
+		last := s.lastReport(r.UserID, r.String("type"))
+		if last == nil {
+			return map[string]any{"data": []types.User{}}, nil
+		}
+		return map[string]any{"data": s.reportView(last, r.UserID).Recipient}, nil
+	}
+}
+
+func reportTypeName(typ string) string {
+	switch typ {
+	case "week":
+		return "周报"
+	case "month":
+		return "月报"
+	}
+	return "日报"
+}
@@ -0,0 +1,480 @@
+// Package dootasktest provides an in-memory fake DooTask server for tests.
+//
+// The server implements the endpoints called by the users, system, approve,
+// dialog, project, file and report services with DooTask's {ret, msg, data}
+// envelope and keeps their state in memory, so integrations can be tested
+// end-to-end against a real sdk.Client:
+//
+//	srv := dootasktest.NewServer()
+//	defer srv.Close()
+//
+//	client := srv.Client()
+//	project, err := client.Project.AddProject(&types.ProjectAddRequest{Name: "Demo"})
+//
+// Endpoints the fake does not implement answer with ret 0. Handle overrides or
+// adds endpoints, and Requests records every call for assertions.
+//
+// To test against real DooTask responses instead, record the traffic of a
+// real server once with Recorder and replay it in CI with Replayer, or let
+// UseCassette pick the mode from the DOOTASK_RECORD environment variable.
+package dootasktest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "github.com/xxyijixx/dootask-golang-sdk"
+	"github.com/xxyijixx/dootask-golang-sdk/types"
+)
+
+// Default credentials of the seeded administrator
+const (
+	DefaultToken    = "dootasktest-token"
+	DefaultEmail    = "admin@dootask.test"
+	DefaultPassword = "123456"
+)
+
+// HandlerFunc serves one endpoint. The returned data becomes the envelope's
+// data field; returning an *Error produces a failed envelope.
+type HandlerFunc func(r *Request) (any, error)
+
+// Error is a DooTask API failure returned by handlers
+type Error struct {
+	Ret int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("ret=%d msg=%s", e.Ret, e.Msg)
+}
+
+// Errorf returns a ret 0 error with a formatted message
+func Errorf(format string, args ...any) *Error {
+	return &Error{Ret: 0, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Request is a request received by the server. Parameters are read from the
+// query string, a urlencoded or multipart form or a JSON body, whichever the
+// client sent.
+type Request struct {
+	Method string
+	Path   string
+	Header http.Header
+	Query  url.Values
+	Body   []byte
+
+	UserID int // authenticated user, 0 for anonymous endpoints
+
+	form  url.Values
+	files map[string][]*multipart.FileHeader
+	json  map[string]any
+}
+
+// Files returns the files uploaded in a multipart form field
+func (r *Request) Files(key string) []*multipart.FileHeader {
+	return r.files[key]
+}
+
+// Has reports whether the parameter was sent
+func (r *Request) Has(key string) bool {
+	if _, ok := r.json[key]; ok {
+		return true
+	}
+	_, ok := r.values(key)
+	return ok
+}
+
+// String returns the parameter as a string, "" when missing
+func (r *Request) String(key string) string {
+	if v, ok := r.json[key]; ok {
+		switch v := v.(type) {
+		case string:
+			return v
+		case nil:
+			return ""
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			if v {
+				return "1"
+			}
+			return "0"
+		default:
+			b, _ := json.Marshal(v)
+			return string(b)
+		}
+	}
+	if vs, ok := r.values(key); ok && len(vs) > 0 {
+		return vs[0]
+	}
+	return ""
+}
+
+// Int returns the parameter as an int, 0 when missing or not a number
+func (r *Request) Int(key string) int {
+	n, _ := strconv.Atoi(r.String(key))
+	return n
+}
+
+// Ints returns a list parameter sent as a JSON array, key[]=1&key[]=2 or a
+// comma separated string
+func (r *Request) Ints(key string) []int {
+	var raw []string
+	if v, ok := r.json[key]; ok {
+		switch v := v.(type) {
+		case []any:
+			for _, item := range v {
+				raw = append(raw, fmt.Sprint(item))
+			}
+		default:
+			raw = strings.Split(r.String(key), ",")
+		}
+	} else if vs, ok := r.values(key + "[]"); ok {
+		raw = vs
+	} else if vs, ok := r.values(key); ok {
+		for _, v := range vs {
+			raw = append(raw, strings.Split(v, ",")...)
+		}
+	}
+
+	var ids []int
+	for _, s := range raw {
+		if n, err := strconv.Atoi(strings.TrimSpace(s)); err == nil {
+			ids = append(ids, n)
+		}
+	}
+	return ids
+}
+
+// Strings returns a list parameter sent as a JSON array or key[]=a&key[]=b
+func (r *Request) Strings(key string) []string {
+	if v, ok := r.json[key].([]any); ok {
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			out = append(out, fmt.Sprint(item))
+		}
+		return out
+	}
+	if vs, ok := r.values(key + "[]"); ok {
+		return vs
+	}
+	vs, _ := r.values(key)
+	return vs
+}
+
+func (r *Request) values(key string) ([]string, bool) {
+	if vs, ok := r.form[key]; ok {
+		return vs, true
+	}
+	vs, ok := r.Query[key]
+	return vs, ok
+}
+
+// Server is an in-memory fake DooTask server backed by httptest.Server
+type Server struct {
+	*httptest.Server
+
+	// Now returns the current time, replace it for deterministic timestamps
+	Now func() time.Time
+
+	mu       sync.Mutex
+	nextID   int
+	handlers map[string]HandlerFunc
+	custom   map[string]HandlerFunc
+	requests []Request
+
+	users     map[int]*user
+	tokens    map[string]int
+	depts     map[int]*types.Department
+	projects  map[int]*types.ProjectDetail
+	tasks     map[int]*types.TaskInfo
+	logs      []types.LogInfo
+	dialogs   map[int]*dialog
+	messages  map[int]*types.MessageItem
+	reads     map[int]map[int]string
+	todos     []*types.DialogTodoItem
+	files     map[int]*types.File
+	contents  map[int][]types.FileContent
+	fileLinks map[int]*types.FileLink
+	fileUsers map[int][]types.FileUser
+	reports   map[int]*report
+
+	settings        map[string]any
+	priorities      []types.SystemPriority
+	columnTemplates []types.SystemColumnTemplate
+
+	procDefs  map[int]*types.ApproveProcDef
+	approvals map[int]*approval
+}
+
+type user struct {
+	types.UserInfo
+	Password string
+}
+
+// NewServer starts a fake server seeded with an administrator (user ID 1)
+// who authenticates with DefaultToken. Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		Now:       time.Now,
+		custom:    make(map[string]HandlerFunc),
+		users:     make(map[int]*user),
+		tokens:    make(map[string]int),
+		depts:     make(map[int]*types.Department),
+		projects:  make(map[int]*types.ProjectDetail),
+		tasks:     make(map[int]*types.TaskInfo),
+		dialogs:   make(map[int]*dialog),
+		messages:  make(map[int]*types.MessageItem),
+		reads:     make(map[int]map[int]string),
+		files:     make(map[int]*types.File),
+		contents:  make(map[int][]types.FileContent),
+		fileLinks: make(map[int]*types.FileLink),
+		fileUsers: make(map[int][]types.FileUser),
+		reports:   make(map[int]*report),
+
+		settings:        defaultSettings(),
+		priorities:      defaultPriorities(),
+		columnTemplates: defaultColumnTemplates(),
+
+		procDefs:  make(map[int]*types.ApproveProcDef),
+		approvals: make(map[int]*approval),
+	}
+	s.handlers = make(map[string]HandlerFunc)
+	s.registerUsers()
+	s.registerDialog()
+	s.registerProject()
+	s.registerFile()
+	s.registerReport()
+	s.registerSystem()
+	s.registerApprove()
+
+	admin := s.AddUser(DefaultEmail, "admin", DefaultPassword)
+	s.users[admin.ID].Identity = []string{"admin"}
+	s.tokens[DefaultToken] = admin.ID
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client returns an sdk.Client for the server authenticated as the
+// administrator. Retries are disabled so failures surface immediately.
+func (s *Server) Client() *sdk.Client {
+	config := sdk.DefaultConfig().
+		WithBaseURL(s.URL).
+		WithToken(DefaultToken).
+		WithRetryCount(0)
+	return sdk.NewClientFromConfig(config)
+}
+
+// Handle serves path with h instead of the built-in handler, if any. h runs
+// without the server lock held, so it may call Server methods such as
+// Requests, AddUser or Handle.
+func (s *Server) Handle(path string, h HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.custom[path] = h
+}
+
+// AddUser creates a user that can log in with email and password and
+// returns it
+func (s *Server) AddUser(email, nickname, password string) types.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	u := &user{
+		UserInfo: types.UserInfo{
+			UserID:     s.id(),
+			Identity:   []string{},
+			Department: []int{},
+			Email:      email,
+			Nickname:   nickname,
+			CreatedAt:  &now,
+			UpdatedAt:  &now,
+		},
+		Password: password,
+	}
+	s.users[u.UserID] = u
+	return u.summary()
+}
+
+// TokenFor returns a token authenticating as userID
+func (s *Server) TokenFor(userID int) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token := fmt.Sprintf("dootasktest-%d-%d", userID, s.id())
+	s.tokens[token] = userID
+	return token
+}
+
+// Requests returns every request received so far, in order
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+// anonymous lists the endpoints served without a token
+var anonymous = map[string]bool{
+	"/api/users/login": true,
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, hr *http.Request) {
+	body, _ := io.ReadAll(hr.Body)
+	r := Request{
+		Method: hr.Method,
+		Path:   hr.URL.Path,
+		Header: hr.Header.Clone(),
+		Query:  hr.URL.Query(),
+		Body:   body,
+	}
+	switch ct := hr.Header.Get("Content-Type"); {
+	case strings.HasPrefix(ct, "application/x-www-form-urlencoded"):
+		r.form, _ = url.ParseQuery(string(body))
+	case strings.HasPrefix(ct, "multipart/form-data"):
+		_, params, _ := mime.ParseMediaType(ct)
+		if form, err := multipart.NewReader(bytes.NewReader(body), params["boundary"]).ReadForm(32 << 20); err == nil {
+			r.form, r.files = form.Value, form.File
+		}
+	case len(body) > 0:
+		json.Unmarshal(body, &r.json)
+	}
+
+	s.mu.Lock()
+	locked := true
+	defer func() {
+		if locked {
+			s.mu.Unlock()
+		}
+	}()
+
+	if !anonymous[r.Path] {
+		userID, ok := s.tokens[hr.Header.Get("Token")]
+		if !ok {
+			s.requests = append(s.requests, r)
+			writeEnvelope(w, -1, "请登录后继续...", nil)
+			return
+		}
+		r.UserID = userID
+	}
+	s.requests = append(s.requests, r)
+
+	h, custom := s.custom[r.Path]
+	if !custom {
+		h = s.handlers[r.Path]
+	}
+	if h == nil {
+		writeEnvelope(w, 0, "dootasktest: endpoint not implemented: "+r.Path, nil)
+		return
+	}
+
+	// Custom handlers run unlocked so they may call Server methods; built-in
+	// handlers work on the server state directly and need s.mu
+	if custom {
+		s.mu.Unlock()
+		locked = false
+	}
+
+	data, err := h(&r)
+	var apiErr *Error
+	switch {
+	case errors.As(err, &apiErr):
+		writeEnvelope(w, apiErr.Ret, apiErr.Msg, data)
+	case err != nil:
+		writeEnvelope(w, 0, err.Error(), nil)
+	default:
+		writeEnvelope(w, 1, "success", data)
+	}
+}
+
+func writeEnvelope(w http.ResponseWriter, ret int, msg string, data any) {
+	if data == nil {
+		data = map[string]any{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"ret": ret, "msg": msg, "data": data})
+}
+
+// id returns the next unique ID, shared by all objects; s.mu must be held
+func (s *Server) id() int {
+	s.nextID++
+	return s.nextID
+}
+
+// now returns the current time as types.DateTime
+func (s *Server) now() types.DateTime {
+	return types.DateTime{Time: s.Now().Truncate(time.Second)}
+}
+
+// nowString returns the current time in DooTask's format
+func (s *Server) nowString() string {
+	return s.Now().Format(time.DateTime)
+}
+
+func (s *Server) userInfo(id int) types.User {
+	if u, ok := s.users[id]; ok {
+		return u.summary()
+	}
+	return types.User{ID: id}
+}
+
+func (s *Server) usersInfo(ids []int) []types.User {
+	out := make([]types.User, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, s.userInfo(id))
+	}
+	return out
+}
+
+// paginate slices items according to the page and pagesize parameters, the
+// way DooTask's Laravel paginator does
+func paginate[T any](r *Request, items []T, defaultSize int) types.Page[T] {
+	current := max(r.Int("page"), 1)
+	size := r.Int("pagesize")
+	if size <= 0 {
+		size = defaultSize
+	}
+	size = min(size, 100)
+
+	last := max((len(items)+size-1)/size, 1)
+	pageURL := func(n int) *string {
+		u := fmt.Sprintf("%s?page=%d", r.Path, n)
+		return &u
+	}
+	p := types.Page[T]{
+		CurrentPage:  current,
+		Data:         []T{},
+		FirstPageUrl: pageURL(1),
+		LastPage:     last,
+		LastPageUrl:  pageURL(last),
+		Links:        []types.PageLink{},
+		Path:         r.Path,
+		PerPage:      size,
+		Total:        len(items),
+	}
+	if current > 1 {
+		p.PrevPageUrl = pageURL(current - 1)
+	}
+	if current < last {
+		p.NextPageUrl = pageURL(current + 1)
+	}
+	if from := (current - 1) * size; from < len(items) {
+		to := min(from+size, len(items))
+		p.Data = items[from:to]
+		from++
+		p.From, p.To = &from, &to
+	}
+	return p
+}
@@ -0,0 +1,63 @@
+package dootasktest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	sdk "github.com/xxyijixx/dootask-golang-sdk"
+)
+
+func TestHandleMayCallServer(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(s *Server) any
+	}{
+		{"Requests", func(s *Server) any { return len(s.Requests()) }},
+		{"AddUser", func(s *Server) any { return s.AddUser("bob@example.com", "bob", "secret").ID }},
+		{"TokenFor", func(s *Server) any { return s.TokenFor(1) != "" }},
+		{"Handle", func(s *Server) any {
+			s.Handle("/api/other", func(*Request) (any, error) { return nil, nil })
+			return true
+		}},
+		{"ProcDef", func(s *Server) any { _, ok := s.ProcDef("leave"); return ok }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := NewServer()
+			srv.Handle("/api/custom", func(*Request) (any, error) { return tt.call(srv), nil })
+
+			done := make(chan error, 1)
+			go func() {
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				resp, err := srv.Client().Do(ctx, &sdk.Request{Method: "GET", Path: "/api/custom"})
+				if err != nil {
+					done <- err
+					return
+				}
+				defer resp.Body.Close()
+				var envelope struct{ Ret int }
+				body, _ := io.ReadAll(resp.Body)
+				if err := json.Unmarshal(body, &envelope); err != nil || envelope.Ret != 1 {
+					t.Errorf("response = %s, want ret 1", body)
+				}
+				done <- nil
+			}()
+
+			select {
+			case err := <-done:
+				if err != nil {
+					t.Fatal(err)
+				}
+			case <-time.After(5 * time.Second):
+				// Closing a deadlocked server would hang the test binary
+				t.Fatal("custom handler deadlocked calling a Server method")
+			}
+			srv.Close()
+		})
+	}
+}
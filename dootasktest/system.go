@@ -0,0 +1,137 @@
+package dootasktest
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+
+	"github.com/xxyijixx/dootask-golang-sdk/types"
+)
+
+// ServerVersion is the version reported by /api/system/version
+const ServerVersion = "0.40.0"
+
+// settingKeys are the system settings the fake accepts
+var settingKeys = []string{
+	"reg", "reg_identity", "reg_invite", "login_code", "password_policy",
+	"project_invite", "chat_information", "anon_message", "voice", "e2e_message",
+	"auto_archived", "archived_day", "task_visible", "all_group_mute",
+	"all_group_autoin", "image_compress", "image_save_local", "start_home",
+	"home_footer", "file_upload_limit",
+}
+
+// defaultSettings mirrors a fresh DooTask installation
+func defaultSettings() map[string]any {
+	return map[string]any{
+		"reg":               "open",
+		"reg_identity":      "normal",
+		"login_code":        "auto",
+		"password_policy":   "simple",
+		"project_invite":    "open",
+		"chat_information":  "optional",
+		"anon_message":      "open",
+		"voice":             "open",
+		"e2e_message":       "close",
+		"auto_archived":     "close",
+		"archived_day":      7,
+		"task_visible":      "close",
+		"all_group_mute":    "open",
+		"all_group_autoin":  "yes",
+		"image_compress":    "open",
+		"image_save_local":  "open",
+		"start_home":        "close",
+		"file_upload_limit": 0,
+	}
+}
+
+func defaultPriorities() []types.SystemPriority {
+	return []types.SystemPriority{
+		{Name: "重要且紧急", Color: "#ED4014", Days: 1, Priority: 1},
+		{Name: "重要不紧急", Color: "#F16B62", Days: 3, Priority: 2},
+		{Name: "紧急不重要", Color: "#19C919", Days: 5, Priority: 3},
+		{Name: "不重要不紧急", Color: "#2D8CF0", Days: 0, Priority: 4},
+	}
+}
+
+func defaultColumnTemplates() []types.SystemColumnTemplate {
+	return []types.SystemColumnTemplate{
+		{Name: "软件开发", Columns: []string{"产品规划", "前端开发", "后端开发", "测试", "发布"}},
+		{Name: "产品开发", Columns: []string{"产品计划", "正在设计", "正在研发", "测试", "准备发布", "发布成功"}},
+	}
+}
+
+// Setting returns the value of a system setting
+func (s *Server) Setting(key string) any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.settings[key]
+}
+
+// listParam decodes the list parameter of a save request into out
+func listParam[T any](r *Request, out *[]T) error {
+	var body struct {
+		List []T `json:"list"`
+	}
+	if err := json.Unmarshal(r.Body, &body); err != nil {
+		return Errorf("参数错误")
+	}
+	*out = body.List
+	return nil
+}
+
+func (s *Server) registerSystem() {
+	h := s.handlers
+
+	h["/api/system/setting"] = func(r *Request) (any, error) {
+		if r.String("type") == "save" {
+			if err := s.requireAdmin(r); err != nil {
+				return nil, err
+			}
+			settings := map[string]any{}
+			for _, key := range settingKeys {
+				if v, ok := r.json[key]; ok {
+					settings[key] = v
+				} else if r.Has(key) {
+					settings[key] = r.String(key)
+				}
+			}
+			s.settings = settings
+		}
+		return maps.Clone(s.settings), nil
+	}
+
+	h["/api/system/priority"] = func(r *Request) (any, error) {
+		if r.String("type") == "save" {
+			if err := s.requireAdmin(r); err != nil {
+				return nil, err
+			}
+			var list []types.SystemPriority
+			if err := listParam(r, &list); err != nil {
+				return nil, err
+			}
+			if len(list) == 0 {
+				return nil, Errorf("参数为空")
+			}
+			s.priorities = list
+		}
+		return slices.Clone(s.priorities), nil
+	}
+
+	h["/api/system/column/template"] = func(r *Request) (any, error) {
+		if r.String("type") == "save" {
+			if err := s.requireAdmin(r); err != nil {
+				return nil, err
+			}
+			var list []types.SystemColumnTemplate
+			if err := listParam(r, &list); err != nil {
+				return nil, err
+			}
+			s.columnTemplates = list
+		}
+		return slices.Clone(s.columnTemplates), nil
+	}
+
+	h["/api/system/version"] = func(r *Request) (any, error) {
+		return types.SystemVersion{Version: ServerVersion}, nil
+	}
+}
@@ -0,0 +1,500 @@
+package dootasktest
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/xxyijixx/dootask-golang-sdk/types"
+)
+
+// User returns a copy of the user with the given ID
+func (s *Server) User(id int) (types.UserInfo, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.users[id]
+	if !ok {
+		return types.UserInfo{}, false
+	}
+	return u.UserInfo, true
+}
+
+// summary returns the user as embedded in project and report payloads
+func (u *user) summary() types.User {
+	return types.User{ID: u.UserID, Email: u.Email, Nickname: u.Nickname, Avatar: u.Userimg}
+}
+
+// basic returns the user as listed by users/search and users/basic
+func (u *user) basic() types.UserBasic {
+	return types.UserBasic{
+		UserID:       u.UserID,
+		Email:        u.Email,
+		Nickname:     u.Nickname,
+		Profession:   u.Profession,
+		Userimg:      u.Userimg,
+		Az:           u.Az,
+		Pinyin:       u.Pinyin,
+		Bot:          u.Bot,
+		Identity:     u.Identity,
+		Department:   u.Department,
+		Introduction: u.Introduction,
+		LineAt:       u.LineAt,
+		DisableAt:    u.DisableAt,
+	}
+}
+
+// issueToken returns a new token authenticating as u
+func (s *Server) issueToken(u *user) string {
+	token := fmt.Sprintf("dootasktest-%d-%d", u.UserID, s.id())
+	s.tokens[token] = u.UserID
+	return token
+}
+
+// revokeTokens invalidates every token of userID
+func (s *Server) revokeTokens(userID int) {
+	for token, id := range s.tokens {
+		if id == userID {
+			delete(s.tokens, token)
+		}
+	}
+}
+
+// az returns the index letter DooTask derives from a nickname
+func az(nickname string) string {
+	if nickname == "" {
+		return ""
+	}
+	c := strings.ToUpper(nickname[:1])
+	if c < "A" || c > "Z" {
+		return "#"
+	}
+	return c
+}
+
+func (s *Server) registerUsers() {
+	h := s.handlers
+
+	h["/api/users/login"] = func(r *Request) (any, error) {
+		email, password := r.String("email"), r.String("password")
+		for _, u := range s.users {
+			if u.Email == email && u.Password == password {
+				if u.DisableAt != nil {
+					return nil, Errorf("帐号已停用...")
+				}
+				info := u.UserInfo
+				info.Token = s.issueToken(u)
+				info.LoginNum++
+				u.LoginNum = info.LoginNum
+				return info, nil
+			}
+		}
+		return nil, Errorf("帐号或密码错误")
+	}
+
+	h["/api/users/logout"] = func(r *Request) (any, error) {
+		delete(s.tokens, r.Header.Get("Token"))
+		return nil, nil
+	}
+
+	h["/api/users/info"] = func(r *Request) (any, error) {
+		return s.users[r.UserID].UserInfo, nil
+	}
+
+	h["/api/users/editdata"] = func(r *Request) (any, error) {
+		u := s.users[r.UserID]
+		if r.Has("nickname") {
+			nickname := strings.TrimSpace(r.String("nickname"))
+			if len([]rune(nickname)) < 2 {
+				return nil, Errorf("昵称不可以少于2个字")
+			}
+			u.Nickname, u.NicknameOriginal, u.Az = nickname, nickname, az(nickname)
+		}
+		if r.Has("userimg") {
+			u.Userimg = r.String("userimg")
+			u.UserimgOriginal = u.Userimg
+		}
+		for key, field := range map[string]*string{
+			"tel":          &u.Tel,
+			"profession":   &u.Profession,
+			"address":      &u.Address,
+			"introduction": &u.Introduction,
+		} {
+			if r.Has(key) {
+				*field = r.String(key)
+			}
+		}
+		if r.Has("birthday") {
+			birthday := r.String("birthday")
+			u.Birthday = &birthday
+		}
+		now := s.now()
+		u.UpdatedAt = &now
+		return u.UserInfo, nil
+	}
+
+	h["/api/users/editpass"] = func(r *Request) (any, error) {
+		u := s.users[r.UserID]
+		if r.String("oldpass") != u.Password {
+			return nil, Errorf("请填写正确的旧密码")
+		}
+		newpass := r.String("newpass")
+		if len(newpass) < 6 {
+			return nil, Errorf("密码设置不能小于6位数")
+		}
+		if newpass == u.Password {
+			return nil, Errorf("新旧密码一致")
+		}
+		u.Password = newpass
+		u.Changepass = 0
+		s.revokeTokens(u.UserID)
+		info := u.UserInfo
+		info.Token = s.issueToken(u)
+		return info, nil
+	}
+
+	h["/api/users/search"] = func(r *Request) (any, error) {
+		var members map[int]bool
+		if id := r.Int("keys[project_id]"); id != 0 {
+			members = s.projectMembers(id)
+		}
+		if id := r.Int("keys[dialog_id]"); id != 0 {
+			members = map[int]bool{}
+			if d, ok := s.dialogs[id]; ok {
+				for _, m := range d.members {
+					members[m] = true
+				}
+			}
+		}
+		var excluded map[int]bool
+		if id := r.Int("keys[no_project_id]"); id != 0 {
+			excluded = s.projectMembers(id)
+		}
+
+		dept := r.Int("keys[department]")
+		key := r.String("keys[key]")
+		disable, bot := r.Int("keys[disable]"), r.Int("keys[bot]")
+		var items []*user
+		for _, u := range s.users {
+			switch {
+			case members != nil && !members[u.UserID], excluded[u.UserID]:
+				continue
+			case dept != 0 && !slices.Contains(u.Department, dept):
+				continue
+			case disable == 0 && u.DisableAt != nil, disable == 1 && u.DisableAt == nil:
+				continue
+			case bot == 0 && u.Bot == 1, bot == 1 && u.Bot == 0:
+				continue
+			case key != "" && !strings.Contains(u.Email, key) && !strings.Contains(u.Nickname, key) && !strings.Contains(u.Profession, key):
+				continue
+			}
+			items = append(items, u)
+		}
+		slices.SortFunc(items, func(a, b *user) int { return cmp.Compare(a.UserID, b.UserID) })
+		if sort := r.String("sorts[az]"); sort != "" {
+			slices.SortStableFunc(items, func(a, b *user) int {
+				if sort == "desc" {
+					return cmp.Compare(b.Az, a.Az)
+				}
+				return cmp.Compare(a.Az, b.Az)
+			})
+		}
+
+		take := min(cmp.Or(r.Int("take"), 10), 100)
+		out := make([]types.UserBasic, 0, min(len(items), take))
+		for _, u := range items[:min(len(items), take)] {
+			out = append(out, u.basic())
+		}
+		return out, nil
+	}
+
+	h["/api/users/department/list"] = func(r *Request) (any, error) {
+		out := make([]types.Department, 0, len(s.depts))
+		for _, d := range s.depts {
+			out = append(out, *d)
+		}
+		slices.SortFunc(out, func(a, b types.Department) int { return cmp.Compare(a.ID, b.ID) })
+		return out, nil
+	}
+
+	h["/api/users/department/add"] = func(r *Request) (any, error) {
+		if err := s.requireAdmin(r); err != nil {
+			return nil, err
+		}
+		name := strings.TrimSpace(r.String("name"))
+		if name == "" {
+			return nil, Errorf("请输入部门名称")
+		}
+		parentID, ownerID := r.Int("parent_id"), r.Int("owner_userid")
+		if _, ok := s.users[ownerID]; !ok {
+			return nil, Errorf("请选择正确的部门负责人")
+		}
+		if _, ok := s.depts[parentID]; parentID != 0 && !ok {
+			return nil, Errorf("上级部门不存在或已被删除")
+		}
+
+		now := s.now()
+		d, ok := s.depts[r.Int("id")]
+		if id := r.Int("id"); id != 0 {
+			if !ok {
+				return nil, Errorf("部门不存在或已被删除")
+			}
+			for p := parentID; p != 0; p = s.depts[p].ParentID {
+				if p == id {
+					return nil, Errorf("不能选择自己或下级部门作为上级部门")
+				}
+			}
+		} else {
+			d = &types.Department{ID: s.id(), CreatedAt: &now}
+			if r.String("dialog_group") == "use" {
+				d.DialogID = r.Int("dialog_useid")
+			} else {
+				d.DialogID = s.newDialog("group", "department", name, ownerID, []int{ownerID}, 0).ID
+			}
+			s.depts[d.ID] = d
+		}
+		d.Name, d.ParentID, d.OwnerUserID, d.UpdatedAt = name, parentID, ownerID, &now
+		if u := s.users[ownerID]; !slices.Contains(u.Department, d.ID) {
+			u.Department = append(u.Department, d.ID)
+		}
+		return *d, nil
+	}
+
+	h["/api/users/department/del"] = func(r *Request) (any, error) {
+		if err := s.requireAdmin(r); err != nil {
+			return nil, err
+		}
+		id := r.Int("id")
+		if _, ok := s.depts[id]; !ok {
+			return nil, Errorf("部门不存在或已被删除")
+		}
+		for _, d := range s.depts {
+			if d.ParentID == id {
+				return nil, Errorf("含有子部门无法删除")
+			}
+		}
+		delete(s.depts, id)
+		for _, u := range s.users {
+			u.Department = slices.DeleteFunc(u.Department, func(v int) bool { return v == id })
+		}
+		return nil, nil
+	}
+
+	h["/api/users/operation"] = func(r *Request) (any, error) {
+		if err := s.requireAdmin(r); err != nil {
+			return nil, err
+		}
+		u, ok := s.users[r.Int("userid")]
+		if !ok {
+			return nil, Errorf("会员不存在或已被删除")
+		}
+		now := s.now()
+		switch r.String("type") {
+		case "":
+		case "setadmin", "settemp":
+			identity := strings.TrimPrefix(r.String("type"), "set")
+			if !slices.Contains(u.Identity, identity) {
+				u.Identity = append(u.Identity, identity)
+			}
+		case "clearadmin", "cleartemp":
+			if u.UserID == r.UserID {
+				return nil, Errorf("不能操作自己")
+			}
+			identity := strings.TrimPrefix(r.String("type"), "clear")
+			u.Identity = slices.DeleteFunc(u.Identity, func(v string) bool { return v == identity })
+		case "department":
+			ids := r.Ints("department")
+			for _, id := range ids {
+				if _, ok := s.depts[id]; !ok {
+					return nil, Errorf("部门不存在或已被删除")
+				}
+			}
+			u.Department = append([]int{}, ids...)
+		case "setdisable":
+			if u.UserID == r.UserID {
+				return nil, Errorf("不能操作自己")
+			}
+			if id := r.Int("transfer_userid"); id != 0 {
+				if _, ok := s.users[id]; !ok || id == u.UserID {
+					return nil, Errorf("请选择正确的交接人")
+				}
+				s.transferUser(u.UserID, id)
+			}
+			u.DisableAt = &now
+			if !slices.Contains(u.Identity, "disable") {
+				u.Identity = append(u.Identity, "disable")
+			}
+			s.revokeTokens(u.UserID)
+		case "cleardisable":
+			u.DisableAt = nil
+			u.Identity = slices.DeleteFunc(u.Identity, func(v string) bool { return v == "disable" })
+		case "delete":
+			if u.UserID == r.UserID {
+				return nil, Errorf("不能删除自己")
+			}
+			delete(s.users, u.UserID)
+			s.revokeTokens(u.UserID)
+			return nil, nil
+		default:
+			return nil, Errorf("参数错误")
+		}
+
+		for key, field := range map[string]*string{
+			"email":      &u.Email,
+			"tel":        &u.Tel,
+			"nickname":   &u.Nickname,
+			"profession": &u.Profession,
+		} {
+			if v := r.String(key); v != "" {
+				*field = v
+			}
+		}
+		if r.Has("email") && s.emailTaken(u.Email, u.UserID) {
+			return nil, Errorf("邮箱地址已存在")
+		}
+		if password := r.String("password"); password != "" {
+			if len(password) < 6 {
+				return nil, Errorf("密码设置不能小于6位数")
+			}
+			u.Password, u.Changepass = password, 1
+			s.revokeTokens(u.UserID)
+		}
+		u.UpdatedAt = &now
+		return u.UserInfo, nil
+	}
+
+	h["/api/users/lists"] = func(r *Request) (any, error) {
+		if err := s.requireAdmin(r); err != nil {
+			return nil, err
+		}
+		key, identity := r.String("keys[key]"), r.String("keys[identity]")
+		disable, dept := r.String("keys[disable]"), r.Int("keys[department]")
+		var items []types.UserInfo
+		for _, u := range s.users {
+			switch {
+			case disable == "" && u.DisableAt != nil, disable == "yes" && u.DisableAt == nil:
+				continue
+			case dept != 0 && !slices.Contains(u.Department, dept):
+				continue
+			case key != "" && !strings.Contains(u.Email, key) && !strings.Contains(u.Nickname, key) && !strings.Contains(u.Profession, key):
+				continue
+			case identity != "" && slices.Contains(u.Identity, strings.TrimPrefix(identity, "no")) == strings.HasPrefix(identity, "no"):
+				continue
+			}
+			items = append(items, u.UserInfo)
+		}
+		slices.SortFunc(items, func(a, b types.UserInfo) int { return cmp.Compare(a.UserID, b.UserID) })
+		return paginate(r, items, 20), nil
+	}
+
+	h["/api/users/import"] = func(r *Request) (any, error) {
+		if err := s.requireAdmin(r); err != nil {
+			return nil, err
+		}
+		var req types.UserImportRequest
+		if err := json.Unmarshal(r.Body, &req); err != nil || len(req.List) == 0 {
+			return nil, Errorf("请填写会员信息")
+		}
+		items := req.List
+		for i, item := range items {
+			if !strings.Contains(item.Email, "@") {
+				return nil, Errorf("邮箱地址错误")
+			}
+			if len(item.Password) < 6 {
+				return nil, Errorf("密码设置不能小于6位数")
+			}
+			if s.emailTaken(item.Email, 0) || slices.ContainsFunc(items[:i], func(o types.UserImportItem) bool { return o.Email == item.Email }) {
+				return nil, Errorf("邮箱地址已存在：%s", item.Email)
+			}
+			for _, id := range item.Department {
+				if _, ok := s.depts[id]; !ok {
+					return nil, Errorf("部门不存在或已被删除")
+				}
+			}
+		}
+		out := make([]types.UserInfo, 0, len(items))
+		for _, item := range items {
+			now := s.now()
+			nickname := cmp.Or(item.Nickname, strings.Split(item.Email, "@")[0])
+			u := &user{
+				UserInfo: types.UserInfo{
+					UserID:     s.id(),
+					Identity:   []string{},
+					Department: append([]int{}, item.Department...),
+					Az:         az(nickname),
+					Email:      item.Email,
+					Tel:        item.Tel,
+					Nickname:   nickname,
+					Profession: item.Profession,
+					Changepass: 1,
+					CreatedAt:  &now,
+					UpdatedAt:  &now,
+				},
+				Password: item.Password,
+			}
+			s.users[u.UserID] = u
+			out = append(out, u.UserInfo)
+		}
+		return out, nil
+	}
+
+	h["/api/users/basic"] = func(r *Request) (any, error) {
+		out := []types.UserBasic{}
+		for _, id := range r.Ints("userid") {
+			if u, ok := s.users[id]; ok {
+				out = append(out, u.basic())
+			}
+		}
+		return out, nil
+	}
+}
+
+// emailTaken reports whether a user other than exceptID uses email
+func (s *Server) emailTaken(email string, exceptID int) bool {
+	for _, u := range s.users {
+		if u.Email == email && u.UserID != exceptID {
+			return true
+		}
+	}
+	return false
+}
+
+// transferUser hands the projects and tasks owned by from over to to
+func (s *Server) transferUser(from, to int) {
+	replace := func(ids []int) []int {
+		if !slices.Contains(ids, from) {
+			return ids
+		}
+		ids = slices.DeleteFunc(ids, func(id int) bool { return id == from })
+		if !slices.Contains(ids, to) {
+			ids = append(ids, to)
+		}
+		return ids
+	}
+	for _, p := range s.projects {
+		p.Owner = replace(p.Owner)
+	}
+	for _, t := range s.tasks {
+		t.Owner = replace(t.Owner)
+	}
+}
+
+// requireAdmin rejects requests from users without the admin identity
+func (s *Server) requireAdmin(r *Request) error {
+	if !slices.Contains(s.users[r.UserID].Identity, "admin") {
+		return Errorf("权限不足")
+	}
+	return nil
+}
+
+// projectMembers returns the IDs of the members of project id
+func (s *Server) projectMembers(id int) map[int]bool {
+	members := map[int]bool{}
+	if p, ok := s.projects[id]; ok {
+		for _, m := range p.Members {
+			members[m.UserID] = true
+		}
+	}
+	return members
+}
@@ -0,0 +1,26 @@
+package sdk
+
+import "github.com/xxyijixx/dootask-golang-sdk/sdkerr"
+
+// Error types returned by the client and services, see package sdkerr
+type (
+	ResponseMeta     = sdkerr.ResponseMeta
+	TransportError   = sdkerr.TransportError
+	AuthError        = sdkerr.AuthError
+	CircuitOpenError = sdkerr.CircuitOpenError
+	HTTPError        = sdkerr.HTTPError
+	APIError         = sdkerr.APIError
+	DecodeError      = sdkerr.DecodeError
+	ValidationError  = sdkerr.ValidationError
+)
+
+// Sentinel errors matched with errors.Is
+var (
+	ErrUnauthorized = sdkerr.ErrUnauthorized
+	ErrForbidden    = sdkerr.ErrForbidden
+	ErrNotFound     = sdkerr.ErrNotFound
+	ErrRateLimited  = sdkerr.ErrRateLimited
+	ErrServer       = sdkerr.ErrServer
+	ErrValidation   = sdkerr.ErrValidation
+	ErrCircuitOpen  = sdkerr.ErrCircuitOpen
+)
@@ -0,0 +1,224 @@
+package sdk
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Metric names reported to Metrics
+const (
+	MetricRequests        = "dootask_requests_total"
+	MetricRequestDuration = "dootask_request_duration_seconds"
+	MetricRetries         = "dootask_retries_total"
+)
+
+// CallInfo identifies an API call
+type CallInfo struct {
+	Method   string
+	Endpoint string // request path without the query string
+}
+
+// CallResult describes how an API call ended
+type CallResult struct {
+	StatusCode int  // final HTTP status, 0 when no response arrived
+	Ret        *int // DooTask ret code of the final response, if any
+	Retries    int  // number of attempts beyond the first
+	Duration   time.Duration
+	Err        error
+}
+
+// Tracer starts a span for every API call, covering all of its retries
+type Tracer interface {
+	// Start returns the context used for the call, allowing the span to be
+	// propagated through middlewares
+	Start(ctx context.Context, call CallInfo) (context.Context, Span)
+}
+
+// Span is ended exactly once per call
+type Span interface {
+	End(result CallResult)
+}
+
+// Metrics receives counters and histogram observations
+type Metrics interface {
+	IncCounter(name string, value float64, labels map[string]string)
+	ObserveHistogram(name string, value float64, labels map[string]string)
+}
+
+// NoopTracer discards spans
+type NoopTracer struct{}
+
+// Start implements Tracer
+func (NoopTracer) Start(ctx context.Context, _ CallInfo) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) End(CallResult) {}
+
+// NoopMetrics discards metrics
+type NoopMetrics struct{}
+
+// IncCounter implements Metrics
+func (NoopMetrics) IncCounter(string, float64, map[string]string) {}
+
+// ObserveHistogram implements Metrics
+func (NoopMetrics) ObserveHistogram(string, float64, map[string]string) {}
+
+// RecordedSpan is a finished span captured by MemoryRecorder
+type RecordedSpan struct {
+	CallInfo
+	CallResult
+}
+
+// MemoryRecorder is an in-memory Tracer and Metrics for tests
+type MemoryRecorder struct {
+	mu         sync.Mutex
+	spans      []RecordedSpan
+	counters   map[string]float64
+	histograms map[string][]float64
+}
+
+// NewMemoryRecorder creates an empty recorder
+func NewMemoryRecorder() *MemoryRecorder {
+	return &MemoryRecorder{
+		counters:   make(map[string]float64),
+		histograms: make(map[string][]float64),
+	}
+}
+
+// Start implements Tracer
+func (r *MemoryRecorder) Start(ctx context.Context, call CallInfo) (context.Context, Span) {
+	return ctx, &memorySpan{recorder: r, call: call}
+}
+
+type memorySpan struct {
+	recorder *MemoryRecorder
+	call     CallInfo
+}
+
+func (s *memorySpan) End(result CallResult) {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	s.recorder.spans = append(s.recorder.spans, RecordedSpan{CallInfo: s.call, CallResult: result})
+}
+
+// IncCounter implements Metrics
+func (r *MemoryRecorder) IncCounter(name string, value float64, labels map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counters[metricKey(name, labels)] += value
+}
+
+// ObserveHistogram implements Metrics
+func (r *MemoryRecorder) ObserveHistogram(name string, value float64, labels map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := metricKey(name, labels)
+	r.histograms[key] = append(r.histograms[key], value)
+}
+
+// Spans returns the finished spans in completion order
+func (r *MemoryRecorder) Spans() []RecordedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.spans)
+}
+
+// Counter returns the value of the counter with exactly the given labels
+func (r *MemoryRecorder) Counter(name string, labels map[string]string) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.counters[metricKey(name, labels)]
+}
+
+// Observations returns the histogram values recorded with exactly the given labels
+func (r *MemoryRecorder) Observations(name string, labels map[string]string) []float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.histograms[metricKey(name, labels)])
+}
+
+// Reset discards everything recorded so far
+func (r *MemoryRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = nil
+	clear(r.counters)
+	clear(r.histograms)
+}
+
+func metricKey(name string, labels map[string]string) string {
+	var b strings.Builder
+	b.WriteString(name)
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		b.WriteString("|" + k + "=" + labels[k])
+	}
+	return b.String()
+}
+
+// instrument wraps an API call with the configured tracer and metrics
+func (c *Client) instrument(ctx context.Context, method, endpoint string, call func(ctx context.Context) (*http.Response, int, error)) (*http.Response, error) {
+	if c.Config.Tracer == nil && c.Config.Metrics == nil {
+		resp, _, err := call(ctx)
+		return resp, err
+	}
+
+	tracer := c.Config.Tracer
+	if tracer == nil {
+		tracer = NoopTracer{}
+	}
+	metrics := c.Config.Metrics
+	if metrics == nil {
+		metrics = NoopMetrics{}
+	}
+
+	info := CallInfo{Method: method, Endpoint: endpoint}
+	if i := strings.IndexByte(endpoint, '?'); i >= 0 {
+		info.Endpoint = endpoint[:i]
+	}
+
+	ctx, span := tracer.Start(ctx, info)
+	start := time.Now()
+	resp, attempts, err := call(ctx)
+
+	result := CallResult{Duration: time.Since(start), Err: err}
+	if attempts > 1 {
+		result.Retries = attempts - 1
+	}
+	if resp != nil {
+		result.StatusCode = resp.StatusCode
+		result.Ret = peekRet(ctx, resp)
+	}
+	span.End(result)
+
+	labels := map[string]string{
+		"method":   info.Method,
+		"endpoint": info.Endpoint,
+		"status":   strconv.Itoa(result.StatusCode),
+		"ret":      "",
+	}
+	if result.Ret != nil {
+		labels["ret"] = strconv.Itoa(*result.Ret)
+	}
+	metrics.IncCounter(MetricRequests, 1, labels)
+	metrics.ObserveHistogram(MetricRequestDuration, result.Duration.Seconds(), map[string]string{
+		"method":   info.Method,
+		"endpoint": info.Endpoint,
+	})
+	if result.Retries > 0 {
+		metrics.IncCounter(MetricRetries, float64(result.Retries), map[string]string{
+			"method":   info.Method,
+			"endpoint": info.Endpoint,
+		})
+	}
+
+	return resp, err
+}
@@ -1,8 +1,217 @@
 package core
 
-import "net/http"
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"sync"
+)
 
-// HTTPDoer abstracts the subset of client behavior needed by services
+// HTTPDoer abstracts the subset of client behavior needed by services.
+// Implement it to mock the client in tests of code built on the services.
 type HTTPDoer interface {
-	DoRequest(method, endpoint string, body interface{}) (*http.Response, error)
+	Do(ctx context.Context, req *Request) (*http.Response, error)
+}
+
+// Request describes an API call
+type Request struct {
+	Method string
+	Path   string     // endpoint path relative to the base URL, e.g. "/api/file/lists"
+	Query  url.Values // added to the query string
+	Header http.Header
+
+	// Params holds the request parameters: encoded into the query string for
+	// GET and HEAD requests and as the body (JSON or form) otherwise
+	Params interface{}
+
+	// Body, when set, is sent as-is instead of encoding Params into the body;
+	// Params are then added to the query string
+	Body Body
+
+	// Stream marks responses whose body is a raw stream such as a download
+	// rather than a DooTask envelope: the client never buffers it, and the
+	// caller must close it
+	Stream bool
+
+	// Idempotent marks endpoints that are safe to repeat, such as reads. Only
+	// these are retried after a 5xx response or a network error: DooTask
+	// sends most writes as GET, so the HTTP method says nothing about it.
+	Idempotent bool
+}
+
+// Body is a request body with its own encoding
+type Body interface {
+	ContentType() string
+	// Open returns a reader over the body. It is called once per attempt;
+	// bodies that cannot be replayed return ErrBodyConsumed after the first.
+	Open() (io.Reader, error)
+}
+
+// ReplayableBody is implemented by bodies that know whether Open can be
+// called more than once. The client does not retry requests whose body
+// reports false.
+type ReplayableBody interface {
+	Body
+	Replayable() bool
+}
+
+// ErrBodyConsumed is returned when a single-use body is opened again
+var ErrBodyConsumed = errors.New("request body cannot be replayed")
+
+// BytesBody returns a body sending b with the given content type
+func BytesBody(contentType string, b []byte) Body {
+	return bytesBody{contentType: contentType, b: b}
+}
+
+type bytesBody struct {
+	contentType string
+	b           []byte
+}
+
+func (b bytesBody) ContentType() string { return b.contentType }
+
+func (b bytesBody) Open() (io.Reader, error) { return bytes.NewReader(b.b), nil }
+
+func (b bytesBody) Replayable() bool { return true }
+
+// ReaderBody returns a body streaming r. Unless r is an io.Seeker the body can
+// only be sent once, which disables retries.
+func ReaderBody(contentType string, r io.Reader) Body {
+	return &readerBody{contentType: contentType, r: r}
+}
+
+type readerBody struct {
+	contentType string
+	r           io.Reader
+
+	mu     sync.Mutex
+	opened bool
+}
+
+func (b *readerBody) ContentType() string { return b.contentType }
+
+func (b *readerBody) Open() (io.Reader, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := rewind(b.r, b.opened); err != nil {
+		return nil, err
+	}
+	b.opened = true
+	// Hide any Close method so sending the request does not close r
+	return struct{ io.Reader }{b.r}, nil
+}
+
+func (b *readerBody) Replayable() bool {
+	_, ok := b.r.(io.Seeker)
+	return ok
+}
+
+// rewind seeks r back to its start before it is read again
+func rewind(r io.Reader, opened bool) error {
+	if !opened {
+		return nil
+	}
+	seeker, ok := r.(io.Seeker)
+	if !ok {
+		return ErrBodyConsumed
+	}
+	_, err := seeker.Seek(0, io.SeekStart)
+	return err
+}
+
+// File is a file part of a multipart body
+type File struct {
+	Field       string    // form field, e.g. "files"
+	Name        string    // file name sent to the server
+	ContentType string    // defaults to application/octet-stream
+	Content     io.Reader // streamed; replayable only when it is an io.Seeker
+}
+
+// MultipartBody returns a multipart/form-data body with the given fields and
+// files. Files are streamed rather than buffered in memory.
+func MultipartBody(fields url.Values, files ...File) Body {
+	return &multipartBody{
+		fields:   fields,
+		files:    files,
+		boundary: multipart.NewWriter(io.Discard).Boundary(),
+	}
+}
+
+type multipartBody struct {
+	fields   url.Values
+	files    []File
+	boundary string
+
+	mu     sync.Mutex
+	opened bool
+}
+
+func (b *multipartBody) ContentType() string {
+	return "multipart/form-data; boundary=" + b.boundary
+}
+
+func (b *multipartBody) Replayable() bool {
+	for _, f := range b.files {
+		if _, ok := f.Content.(io.Seeker); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *multipartBody) Open() (io.Reader, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, f := range b.files {
+		if err := rewind(f.Content, b.opened); err != nil {
+			return nil, err
+		}
+	}
+	b.opened = true
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(b.write(pw))
+	}()
+	return pr, nil
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func (b *multipartBody) write(w io.Writer) error {
+	mw := multipart.NewWriter(w)
+	if err := mw.SetBoundary(b.boundary); err != nil {
+		return err
+	}
+	for key, values := range b.fields {
+		for _, v := range values {
+			if err := mw.WriteField(key, v); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range b.files {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, quoteEscaper.Replace(f.Field), quoteEscaper.Replace(f.Name)))
+		contentType := f.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		header.Set("Content-Type", contentType)
+		part, err := mw.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(part, f.Content); err != nil {
+			return err
+		}
+	}
+	return mw.Close()
 }
@@ -0,0 +1,228 @@
+package http
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EncodeValues converts a request struct tagged with `json` tags, a map or
+// url.Values into url.Values suitable for a query string or form body.
+//
+// Fields tagged "-" are skipped, omitempty drops zero values, nil pointers are
+// always dropped. Slices become repeated `key[]` entries and maps become
+// `key[sub]` entries, matching what DooTask's Laravel backend expects.
+func EncodeValues(v interface{}) (url.Values, error) {
+	values := url.Values{}
+	if v == nil {
+		return values, nil
+	}
+	if uv, ok := v.(url.Values); ok {
+		return uv, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return values, nil
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		if err := encodeStruct(values, rv); err != nil {
+			return nil, err
+		}
+	case reflect.Map:
+		if err := encodeMap(values, "", rv); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("cannot encode %s as url values", rv.Type())
+	}
+
+	return values, nil
+}
+
+// AppendQuery appends values to the query string of rawURL, keeping any
+// query parameters already present
+func AppendQuery(rawURL string, values url.Values) string {
+	if len(values) == 0 {
+		return rawURL
+	}
+	sep := "?"
+	if strings.Contains(rawURL, "?") {
+		sep = "&"
+	}
+	return rawURL + sep + values.Encode()
+}
+
+func encodeStruct(values url.Values, rv reflect.Value) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		// Like encoding/json, promote the fields of embedded structs even when
+		// the struct type itself is unexported
+		if !field.IsExported() && !(field.Anonymous && field.Type.Kind() == reflect.Struct) {
+			continue
+		}
+
+		name, omitempty := parseTag(field)
+		if name == "-" {
+			continue
+		}
+
+		fv := rv.Field(i)
+		if field.Anonymous && name == "" {
+			for fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					break
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				if err := encodeStruct(values, fv); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		if omitempty && fv.IsZero() {
+			continue
+		}
+		if err := encodeValue(values, name, fv, omitempty); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func encodeMap(values url.Values, prefix string, rv reflect.Value) error {
+	keys := make([]string, 0, rv.Len())
+	byKey := make(map[string]reflect.Value, rv.Len())
+	for _, k := range rv.MapKeys() {
+		ks := fmt.Sprint(k.Interface())
+		keys = append(keys, ks)
+		byKey[ks] = rv.MapIndex(k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		name := k
+		if prefix != "" {
+			name = prefix + "[" + k + "]"
+		}
+		if err := encodeValue(values, name, byKey[k], false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func encodeValue(values url.Values, name string, fv reflect.Value, omitempty bool) error {
+	for fv.Kind() == reflect.Pointer || fv.Kind() == reflect.Interface {
+		if fv.IsNil() {
+			return nil
+		}
+		fv = fv.Elem()
+	}
+
+	if s, ok, err := marshalScalar(fv); ok || err != nil {
+		if err != nil {
+			return err
+		}
+		values.Add(name, s)
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.Uint8 {
+			values.Add(name, string(fv.Bytes()))
+			return nil
+		}
+		if fv.Len() == 0 && omitempty {
+			return nil
+		}
+		for i := 0; i < fv.Len(); i++ {
+			if err := encodeValue(values, name+"[]", fv.Index(i), false); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		return encodeMap(values, name, fv)
+	case reflect.Struct:
+		b, err := json.Marshal(fv.Interface())
+		if err != nil {
+			return err
+		}
+		values.Add(name, string(b))
+		return nil
+	}
+
+	return fmt.Errorf("cannot encode field %s of type %s", name, fv.Type())
+}
+
+// marshalScalar formats basic kinds and types implementing text or JSON
+// marshalling; ok is false for composite values
+func marshalScalar(fv reflect.Value) (string, bool, error) {
+	if fv.CanInterface() {
+		switch m := fv.Interface().(type) {
+		case encoding.TextMarshaler:
+			b, err := m.MarshalText()
+			return string(b), true, err
+		case json.Marshaler:
+			b, err := m.MarshalJSON()
+			if err != nil {
+				return "", true, err
+			}
+			var s string
+			if json.Unmarshal(b, &s) == nil {
+				return s, true, nil
+			}
+			return string(b), true, nil
+		}
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		return fv.String(), true, nil
+	case reflect.Bool:
+		if fv.Bool() {
+			return "1", true, nil
+		}
+		return "0", true, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(fv.Int(), 10), true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(fv.Uint(), 10), true, nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(fv.Float(), 'f', -1, fv.Type().Bits()), true, nil
+	}
+	return "", false, nil
+}
+
+func parseTag(field reflect.StructField) (name string, omitempty bool) {
+	tag := field.Tag.Get("json")
+	if tag == "" {
+		return "", false
+	}
+	parts := strings.Split(tag, ",")
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" || opt == "omitzero" {
+			omitempty = true
+		}
+	}
+	return parts[0], omitempty
+}
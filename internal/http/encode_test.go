@@ -0,0 +1,183 @@
+package http
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testEmbedded struct {
+	Page     int `json:"page,omitempty"`
+	PageSize int `json:"pagesize,omitempty"`
+}
+
+// Paging is exported so it can be embedded through a pointer
+type Paging struct {
+	Page int `json:"page"`
+}
+
+type testNested struct {
+	Name string `json:"name"`
+	Open bool   `json:"open"`
+}
+
+type testQuoted struct{ s string }
+
+func (q testQuoted) MarshalJSON() ([]byte, error) { return []byte(`"` + q.s + `"`), nil }
+
+type testNumber struct{}
+
+func (testNumber) MarshalJSON() ([]byte, error) { return []byte(`42`), nil }
+
+func TestEncodeValues(t *testing.T) {
+	zero, one := 0, 1
+	when := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		in   interface{}
+		want string
+	}{
+		{"nil", nil, ""},
+		{"nil pointer", (*testNested)(nil), ""},
+		{"url values pass through", url.Values{"a": {"1", "2"}}, "a=1&a=2"},
+		{"basic kinds", struct {
+			S string  `json:"s"`
+			I int64   `json:"i"`
+			U uint8   `json:"u"`
+			F float64 `json:"f"`
+			T bool    `json:"t"`
+			B bool    `json:"b"`
+		}{"x y", -3, 7, 1.5, true, false}, "b=0&f=1.5&i=-3&s=x+y&t=1&u=7"},
+		{"omitempty drops zero values", struct {
+			A string `json:"a,omitempty"`
+			B int    `json:"b,omitempty"`
+			C bool   `json:"c,omitempty"`
+			D []int  `json:"d,omitempty"`
+			E int    `json:"e"`
+		}{}, "e=0"},
+		{"omitzero acts as omitempty", struct {
+			A int `json:"a,omitzero"`
+		}{}, ""},
+		{"skipped and unexported fields", struct {
+			A string `json:"-"`
+			b string
+			C string `json:"c"`
+		}{"a", "b", "c"}, "c=c"},
+		{"untagged field uses its name", struct{ Name string }{"n"}, "Name=n"},
+		{"pointers", struct {
+			Nil  *int `json:"nil"`
+			Zero *int `json:"zero,omitempty"`
+			One  *int `json:"one"`
+		}{nil, &zero, &one}, "one=1&zero=0"},
+		{"slices repeat with brackets", struct {
+			IDs []int `json:"userid"`
+		}{[]int{3, 1, 2}}, "userid%5B%5D=3&userid%5B%5D=1&userid%5B%5D=2"},
+		{"empty slice without omitempty", struct {
+			IDs []int `json:"ids"`
+		}{[]int{}}, ""},
+		{"arrays", struct {
+			A [2]string `json:"a"`
+		}{[2]string{"x", "y"}}, "a%5B%5D=x&a%5B%5D=y"},
+		{"byte slices are strings", struct {
+			B []byte `json:"b"`
+		}{[]byte("raw")}, "b=raw"},
+		{"maps become sub keys", struct {
+			Keys map[string]interface{} `json:"keys"`
+		}{map[string]interface{}{"name": "x", "tag": []string{"a", "b"}}},
+			"keys%5Bname%5D=x&keys%5Btag%5D%5B%5D=a&keys%5Btag%5D%5B%5D=b"},
+		{"nested maps", map[string]interface{}{"a": map[string]int{"b": 1}}, "a%5Bb%5D=1"},
+		{"top level map", map[string]int{"b": 2, "a": 1}, "a=1&b=2"},
+		{"literal bracket tags", struct {
+			Key     string `json:"keys[key],omitempty"`
+			Disable int    `json:"keys[disable],omitempty"`
+		}{Key: "bob"}, "keys%5Bkey%5D=bob"},
+		{"embedded structs are flattened", struct {
+			testEmbedded
+			Name string `json:"name"`
+		}{testEmbedded{Page: 2}, "n"}, "name=n&page=2"},
+		{"embedded pointer structs are flattened", struct {
+			*Paging
+		}{&Paging{Page: 3}}, "page=3"},
+		{"nil embedded pointers are dropped", struct {
+			*Paging
+			Name string `json:"name"`
+		}{nil, "n"}, "name=n"},
+		{"nested structs are JSON", struct {
+			Item testNested `json:"item"`
+		}{testNested{"n", true}}, "item=%7B%22name%22%3A%22n%22%2C%22open%22%3Atrue%7D"},
+		{"text marshaler", struct {
+			At time.Time `json:"at"`
+		}{when}, "at=2024-05-06T07%3A08%3A09Z"},
+		{"json marshaler string", struct {
+			Q testQuoted `json:"q"`
+		}{testQuoted{"hi"}}, "q=hi"},
+		{"json marshaler number", struct {
+			N testNumber `json:"n"`
+		}{testNumber{}}, "n=42"},
+		{"interface fields", struct {
+			V interface{} `json:"v"`
+			N interface{} `json:"n"`
+		}{V: 5}, "v=5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := EncodeValues(tt.in)
+			if err != nil {
+				t.Fatalf("EncodeValues: %v", err)
+			}
+			if got := values.Encode(); got != tt.want {
+				t.Errorf("EncodeValues = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeValuesErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   interface{}
+		want string
+	}{
+		{"scalar", 5, "cannot encode int"},
+		{"slice", []int{1}, "cannot encode []int"},
+		{"channel field", struct {
+			C chan int `json:"c"`
+		}{make(chan int)}, "cannot encode field c"},
+		{"function in map", map[string]interface{}{"f": func() {}}, "cannot encode field f"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := EncodeValues(tt.in)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("EncodeValues error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppendQuery(t *testing.T) {
+	tests := []struct {
+		name   string
+		rawURL string
+		values url.Values
+		want   string
+	}{
+		{"no values", "http://x/api", nil, "http://x/api"},
+		{"empty values", "http://x/api?a=1", url.Values{}, "http://x/api?a=1"},
+		{"new query", "http://x/api", url.Values{"b": {"2"}}, "http://x/api?b=2"},
+		{"existing query", "http://x/api?a=1", url.Values{"b": {"2"}}, "http://x/api?a=1&b=2"},
+		{"values are escaped", "/api", url.Values{"k[]": {"a b"}}, "/api?k%5B%5D=a+b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AppendQuery(tt.rawURL, tt.values); got != tt.want {
+				t.Errorf("AppendQuery = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,32 +2,33 @@ package http
 
 import (
 	"encoding/json"
-	"fmt"
+	"sort"
 	"strings"
+
+	"github.com/xxyijixx/dootask-golang-sdk/sdkerr"
 )
 
 // APIError represents a DooTask API error
-type APIError struct {
-	Ret int    `json:"ret"`
-	Msg string `json:"msg"`
-}
-
-func (e APIError) Error() string {
-	return fmt.Sprintf("API error [%d]: %s", e.Ret, e.Msg)
-}
+type APIError = sdkerr.APIError
 
-// HandleError processes the HTTP response and returns an error if the status code indicates a failure.
+// HandleError converts a response with a failing status code into an *sdkerr.HTTPError.
 func HandleError(statusCode int, body []byte) error {
-	var apiError APIError
-	if err := json.Unmarshal(body, &apiError); err != nil {
-		// If we can't parse the error response, return a generic error
-		return fmt.Errorf("HTTP %d: %s", statusCode, string(body))
+	httpErr := &sdkerr.HTTPError{
+		StatusCode: statusCode,
+		Msg:        string(body),
 	}
-	// If the API error doesn't have ret field set, use the HTTP status code
-	if apiError.Ret == 0 {
-		apiError.Ret = statusCode
+
+	// Surface ret/msg when the server still replied with a DooTask envelope
+	var envelope struct {
+		Ret int    `json:"ret"`
+		Msg string `json:"msg"`
+	}
+	if err := json.Unmarshal(body, &envelope); err == nil && envelope.Msg != "" {
+		httpErr.Ret = envelope.Ret
+		httpErr.Msg = envelope.Msg
 	}
-	return apiError
+
+	return httpErr
 }
 
 // ValidateRequired validates that required fields are not empty
@@ -52,7 +53,8 @@ func ValidateRequired(fields map[string]interface{}) error {
 	}
 
 	if len(missing) > 0 {
-		return fmt.Errorf("missing required fields: %s", strings.Join(missing, ", "))
+		sort.Strings(missing)
+		return &sdkerr.ValidationError{Fields: missing, Msg: "missing required fields"}
 	}
 
 	return nil
@@ -1,43 +1,134 @@
 package http
 
 import (
+	"bytes"
 	"encoding/json"
-	"fmt"
-	"log"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 )
 
-// LogRequest logs HTTP request details for debugging
-func LogRequest(method, url string, body interface{}) {
-	log.Printf("[REQUEST] %s %s", method, url)
-	if body != nil {
-		if bodyStr, err := json.MarshalIndent(body, "", "  "); err == nil {
-			log.Printf("[REQUEST BODY] %s", string(bodyStr))
+// Redacted replaces sensitive values in logs
+const Redacted = "[REDACTED]"
+
+// RedactHeaders returns a copy of header with the values of sensitive headers replaced
+func RedactHeaders(header http.Header, sensitive ...string) http.Header {
+	out := header.Clone()
+	for _, name := range sensitive {
+		if out.Get(name) != "" {
+			out.Set(name, Redacted)
 		}
 	}
+	return out
 }
 
-// LogResponse logs HTTP response details for debugging
-func LogResponse(resp *http.Response) {
-	log.Printf("[RESPONSE] %s %d", resp.Request.Method, resp.StatusCode)
+// RedactRequestURI returns the path and query of u with the values of
+// sensitive query parameters replaced
+func RedactRequestURI(u *url.URL, fields []string) string {
+	if u.RawQuery == "" {
+		return u.EscapedPath()
+	}
+	return u.EscapedPath() + "?" + RedactValues(u.Query(), fields).Encode()
 }
 
-// BuildQueryString builds a query string from parameters
-func BuildQueryString(params map[string]interface{}) string {
-	if len(params) == 0 {
+// RedactValues returns a copy of values with sensitive keys replaced.
+// Keys are compared case-insensitively and without array suffixes like "[]".
+func RedactValues(values url.Values, fields []string) url.Values {
+	out := make(url.Values, len(values))
+	for key, vs := range values {
+		if isSensitive(key, fields) {
+			out[key] = []string{Redacted}
+			continue
+		}
+		out[key] = vs
+	}
+	return out
+}
+
+// RedactBody returns a printable copy of a JSON or urlencoded body with
+// sensitive fields replaced, truncated to limit bytes when limit > 0
+func RedactBody(body []byte, fields []string, limit int) string {
+	if len(body) == 0 {
 		return ""
 	}
 
-	var parts []string
-	for key, value := range params {
-		if value != nil {
-			parts = append(parts, fmt.Sprintf("%s=%v", key, value))
+	var out string
+	trimmed := bytes.TrimSpace(body)
+	if b, ok := RedactJSON(trimmed, fields); ok {
+		out = string(b)
+	} else if values, err := url.ParseQuery(string(trimmed)); err == nil && isPrintable(trimmed) {
+		out = RedactValues(values, fields).Encode()
+	} else {
+		return "[" + strconv.Itoa(len(body)) + " bytes]"
+	}
+
+	if limit > 0 && len(out) > limit {
+		out = out[:limit] + "...(truncated)"
+	}
+	return out
+}
+
+// RedactJSON returns a compact copy of a JSON document with sensitive fields
+// replaced, or false when body is not JSON. Numbers are kept verbatim.
+func RedactJSON(body []byte, fields []string) ([]byte, bool) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil || dec.More() {
+		return nil, false
+	}
+	out, err := json.Marshal(redactJSON(doc, fields))
+	if err != nil {
+		return nil, false
+	}
+	return out, true
+}
+
+func redactJSON(v interface{}, fields []string) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for key, value := range t {
+			if isSensitive(key, fields) {
+				t[key] = Redacted
+				continue
+			}
+			t[key] = redactJSON(value, fields)
+		}
+	case []interface{}:
+		for i, value := range t {
+			t[i] = redactJSON(value, fields)
+		}
+	}
+	return v
+}
+
+func isSensitive(key string, fields []string) bool {
+	if i := strings.IndexByte(key, '['); i > 0 {
+		key = key[:i]
+	}
+	for _, f := range fields {
+		if strings.EqualFold(key, f) {
+			return true
 		}
 	}
+	return false
+}
 
-	if len(parts) > 0 {
-		return "?" + strings.Join(parts, "&")
+func isPrintable(b []byte) bool {
+	for _, c := range b {
+		if c < 0x20 || c == 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+// BuildQueryString builds a query string from parameters
+func BuildQueryString(params map[string]interface{}) string {
+	values, err := EncodeValues(params)
+	if err != nil || len(values) == 0 {
+		return ""
 	}
-	return ""
+	return "?" + values.Encode()
 }
@@ -2,9 +2,11 @@ package http
 
 import (
 	"encoding/json"
-	"fmt"
+	"errors"
 	"io"
 	"net/http"
+
+	"github.com/xxyijixx/dootask-golang-sdk/sdkerr"
 )
 
 // APIResponse represents the standard DooTask API response structure
@@ -16,33 +18,43 @@ type APIResponse[T any] struct {
 
 // ParseResponse parses the HTTP response and decodes the JSON into the provided interface.
 func ParseResponse(resp *http.Response, result interface{}) error {
+	_, err := parseResponse(resp, result)
+	return err
+}
+
+// parseResponse is ParseResponse that also returns the response metadata for
+// callers that attach it to later errors
+func parseResponse(resp *http.Response, result interface{}) (*sdkerr.ResponseMeta, error) {
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		meta := sdkerr.NewResponseMeta(resp, nil)
+		return meta, &sdkerr.TransportError{Method: meta.Method, URL: meta.URL, Err: err}
 	}
+	meta := sdkerr.NewResponseMeta(resp, body)
 
 	if resp.StatusCode >= 400 {
-		return HandleError(resp.StatusCode, body)
+		httpErr := HandleError(resp.StatusCode, body).(*sdkerr.HTTPError)
+		httpErr.Response = meta
+		return meta, httpErr
 	}
 
-	return json.Unmarshal(body, result)
+	if err := json.Unmarshal(body, result); err != nil {
+		return meta, &sdkerr.DecodeError{Err: err, Response: meta}
+	}
+	return meta, nil
 }
 
 // ParseAPIResponse parses a standard DooTask API response
 func ParseAPIResponse[T any](resp *http.Response, result *T) error {
-	var apiResp APIResponse[T]
-	if err := ParseResponse(resp, &apiResp); err != nil {
+	data, err := ParseAPIResponseToStruct[T](resp)
+	if err != nil {
 		return err
 	}
 
-	if apiResp.Ret != 1 {
-		return APIError{Ret: apiResp.Ret, Msg: apiResp.Msg}
-	}
-
 	if result != nil {
-		*result = apiResp.Data
+		*result = data
 	}
 
 	return nil
@@ -53,13 +65,36 @@ func ParseAPIResponseToStruct[T any](resp *http.Response) (T, error) {
 	var zero T
 
 	var apiResp APIResponse[T]
-	if err := ParseResponse(resp, &apiResp); err != nil {
+	meta, err := parseResponse(resp, &apiResp)
+	if err != nil {
+		// A failed call often carries data of a different shape than T, so
+		// report the envelope error rather than the decode failure
+		var decodeErr *sdkerr.DecodeError
+		if errors.As(err, &decodeErr) {
+			if apiErr := envelopeError(meta); apiErr != nil {
+				return zero, apiErr
+			}
+		}
 		return zero, err
 	}
 
 	if apiResp.Ret != 1 {
-		return zero, APIError{Ret: apiResp.Ret, Msg: apiResp.Msg}
+		if apiErr := envelopeError(meta); apiErr != nil {
+			return zero, apiErr
+		}
+		return zero, APIError{Ret: apiResp.Ret, Msg: apiResp.Msg, Response: meta}
 	}
 
 	return apiResp.Data, nil
 }
+
+// envelopeError returns an APIError when the body in meta is a DooTask
+// envelope whose ret is not 1, nil otherwise
+func envelopeError(meta *sdkerr.ResponseMeta) error {
+	var apiErr APIError
+	if meta == nil || json.Unmarshal(meta.Body, &apiErr) != nil || apiErr.Ret == 1 {
+		return nil
+	}
+	apiErr.Response = meta
+	return apiErr
+}
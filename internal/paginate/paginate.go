@@ -0,0 +1,44 @@
+package paginate
+
+import (
+	"context"
+	"iter"
+
+	"github.com/xxyijixx/dootask-golang-sdk/types"
+)
+
+// FetchFunc fetches one page of a paginated endpoint
+type FetchFunc[T any] func(ctx context.Context, page int) (*types.Page[T], error)
+
+// Pages yields the items of every page returned by fetch, starting at page
+// start (1 when start <= 0). Iteration stops after the last page, when the
+// caller stops, or after yielding the first error, which is also reported
+// when ctx is done between pages.
+func Pages[T any](ctx context.Context, start int, fetch FetchFunc[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		page := max(start, 1)
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			p, err := fetch(ctx, page)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range p.Data {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			// Guard against servers that repeat the same page forever
+			next := p.NextPage()
+			if len(p.Data) == 0 || next <= page {
+				return
+			}
+			page = next
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package sdk
+
+import "iter"
+
+// Collect gathers the items of an iterator such as Project.AllTasks into a
+// slice, stopping after limit items when limit > 0. It returns the items read
+// so far together with the first error.
+func Collect[T any](seq iter.Seq2[T, error], limit int) ([]T, error) {
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			return items, err
+		}
+		items = append(items, item)
+		if limit > 0 && len(items) >= limit {
+			break
+		}
+	}
+	return items, nil
+}
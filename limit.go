@@ -0,0 +1,195 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xxyijixx/dootask-golang-sdk/sdkerr"
+)
+
+// Limit configures client-side throttling for requests whose path starts with Prefix.
+// Every matching limit applies, so a global limit (empty Prefix) can be combined
+// with stricter ones such as "/api/dialog/msg/".
+type Limit struct {
+	Prefix      string  // endpoint path prefix relative to the base URL, empty matches every request
+	Rate        float64 // sustained requests per second, 0 disables rate limiting
+	Burst       int     // bucket size, defaults to 1 when Rate is set
+	MaxInFlight int     // maximum concurrent requests, 0 means unlimited
+	FailFast    bool    // return an error instead of waiting for capacity
+}
+
+// limiter enforces a single Limit
+type limiter struct {
+	limit  Limit
+	bucket *tokenBucket
+	slots  chan struct{}
+}
+
+func newLimiter(l Limit) *limiter {
+	lim := &limiter{limit: l}
+	if l.Rate > 0 {
+		lim.bucket = newTokenBucket(l.Rate, l.Burst)
+	}
+	if l.MaxInFlight > 0 {
+		lim.slots = make(chan struct{}, l.MaxInFlight)
+	}
+	return lim
+}
+
+// acquire waits for capacity and returns a func releasing the in-flight slot
+func (l *limiter) acquire(ctx context.Context) (func(), error) {
+	if l.slots != nil {
+		if l.limit.FailFast {
+			select {
+			case l.slots <- struct{}{}:
+			default:
+				return nil, l.rejected("too many requests in flight")
+			}
+		} else {
+			select {
+			case l.slots <- struct{}{}:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+	}
+
+	release := func() {
+		if l.slots != nil {
+			<-l.slots
+		}
+	}
+
+	if l.bucket != nil {
+		if err := l.bucket.wait(ctx, l.limit.FailFast); err != nil {
+			release()
+			if err == errBucketEmpty {
+				return nil, l.rejected("rate limit exceeded")
+			}
+			return nil, err
+		}
+	}
+
+	return release, nil
+}
+
+func (l *limiter) rejected(reason string) error {
+	return fmt.Errorf("%w: %s for prefix %q", sdkerr.ErrRateLimited, reason, l.limit.Prefix)
+}
+
+var errBucketEmpty = errors.New("token bucket empty")
+
+// tokenBucket is a token bucket refilled continuously at rate tokens per second
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait takes one token, sleeping until it is available unless failFast is set
+func (b *tokenBucket) wait(ctx context.Context, failFast bool) error {
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		b.mu.Unlock()
+		return nil
+	}
+	if failFast {
+		b.mu.Unlock()
+		return errBucketEmpty
+	}
+
+	// Reserve the token now so concurrent waiters queue up behind each other
+	delay := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	b.tokens--
+	b.mu.Unlock()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+type endpointCtxKey struct{}
+
+// withEndpoint records the endpoint path of a call, relative to the base URL
+func withEndpoint(ctx context.Context, path string) context.Context {
+	path, _, _ = strings.Cut(path, "?")
+	return context.WithValue(ctx, endpointCtxKey{}, path)
+}
+
+// endpointPath returns the endpoint path of req. Requests not made through
+// Client.Do fall back to the URL path, which includes any base URL path.
+func endpointPath(req *http.Request) string {
+	if path, ok := req.Context().Value(endpointCtxKey{}).(string); ok {
+		return path
+	}
+	return req.URL.Path
+}
+
+// LimitMiddleware throttles requests according to limits. The returned
+// middleware keeps its own state, so build it once and share it.
+func LimitMiddleware(limits ...Limit) Middleware {
+	limiters := make([]*limiter, 0, len(limits))
+	for _, l := range limits {
+		limiters = append(limiters, newLimiter(l))
+	}
+
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			var releases []func()
+			defer func() {
+				for _, release := range releases {
+					release()
+				}
+			}()
+
+			path := endpointPath(req)
+			for _, l := range limiters {
+				if !strings.HasPrefix(path, l.limit.Prefix) {
+					continue
+				}
+				release, err := l.acquire(req.Context())
+				if err != nil {
+					return nil, err
+				}
+				releases = append(releases, release)
+			}
+
+			return next(req)
+		}
+	}
+}
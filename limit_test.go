@@ -0,0 +1,245 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/xxyijixx/dootask-golang-sdk/sdkerr"
+)
+
+func TestTokenBucketBurst(t *testing.T) {
+	tests := []struct {
+		name  string
+		rate  float64
+		burst int
+		takes int
+		want  int
+	}{
+		{"burst of three", 0.001, 3, 5, 3},
+		{"zero burst defaults to one", 0.001, 0, 3, 1},
+		{"negative burst defaults to one", 0.001, -4, 3, 1},
+		{"takes within burst", 0.001, 10, 4, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newTokenBucket(tt.rate, tt.burst)
+			got := 0
+			for i := 0; i < tt.takes; i++ {
+				err := b.wait(context.Background(), true)
+				switch {
+				case err == nil:
+					got++
+				case err != errBucketEmpty:
+					t.Fatalf("wait = %v, want nil or errBucketEmpty", err)
+				}
+			}
+			if got != tt.want {
+				t.Errorf("granted %d tokens, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenBucketRefill(t *testing.T) {
+	b := newTokenBucket(50, 1)
+	if err := b.wait(context.Background(), true); err != nil {
+		t.Fatalf("first wait = %v", err)
+	}
+	if err := b.wait(context.Background(), true); err != errBucketEmpty {
+		t.Fatalf("second wait = %v, want errBucketEmpty", err)
+	}
+
+	time.Sleep(40 * time.Millisecond)
+	if err := b.wait(context.Background(), true); err != nil {
+		t.Errorf("wait after refill = %v", err)
+	}
+}
+
+func TestTokenBucketWaits(t *testing.T) {
+	b := newTokenBucket(20, 1)
+	if err := b.wait(context.Background(), false); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	if err := b.wait(context.Background(), false); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("second token granted after %v, want about 50ms", elapsed)
+	}
+}
+
+func TestTokenBucketCancelReturnsToken(t *testing.T) {
+	b := newTokenBucket(1, 1)
+	if err := b.wait(context.Background(), false); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := b.wait(ctx, false); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("wait = %v, want context.DeadlineExceeded", err)
+	}
+
+	b.mu.Lock()
+	tokens := b.tokens
+	b.mu.Unlock()
+	if tokens < 0 {
+		t.Errorf("tokens = %v after cancelled wait, want the reservation returned", tokens)
+	}
+}
+
+func TestLimiterInFlight(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    Limit
+		wantErr  error
+		rejected bool
+	}{
+		{"fail fast", Limit{MaxInFlight: 2, FailFast: true}, sdkerr.ErrRateLimited, true},
+		{"wait until deadline", Limit{MaxInFlight: 2}, context.DeadlineExceeded, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newLimiter(tt.limit)
+			var releases []func()
+			for i := 0; i < tt.limit.MaxInFlight; i++ {
+				release, err := l.acquire(context.Background())
+				if err != nil {
+					t.Fatalf("acquire %d = %v", i, err)
+				}
+				releases = append(releases, release)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			if _, err := l.acquire(ctx); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("acquire over limit = %v, want %v", err, tt.wantErr)
+			} else if got := errors.Is(err, sdkerr.ErrRateLimited); got != tt.rejected {
+				t.Errorf("errors.Is(err, ErrRateLimited) = %v, want %v", got, tt.rejected)
+			}
+
+			releases[0]()
+			release, err := l.acquire(context.Background())
+			if err != nil {
+				t.Fatalf("acquire after release = %v", err)
+			}
+			release()
+		})
+	}
+}
+
+func TestLimiterRateRejectionReleasesSlot(t *testing.T) {
+	l := newLimiter(Limit{Rate: 0.001, Burst: 1, MaxInFlight: 1, FailFast: true})
+	release, err := l.acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+
+	if _, err := l.acquire(context.Background()); !errors.Is(err, sdkerr.ErrRateLimited) {
+		t.Fatalf("acquire = %v, want ErrRateLimited", err)
+	}
+	if n := len(l.slots); n != 0 {
+		t.Errorf("%d slots held after a rate limit rejection, want 0", n)
+	}
+}
+
+func TestLimitMiddlewarePrefix(t *testing.T) {
+	tests := []struct {
+		name    string
+		base    string
+		path    string
+		prefix  string
+		limited bool
+	}{
+		{"global limit", "", "/api/users/info", "", true},
+		{"matching prefix", "", "/api/dialog/msg/sendtext", "/api/dialog/msg/", true},
+		{"other prefix", "", "/api/users/info", "/api/dialog/msg/", false},
+		{"sub-path deployment", "/dootask", "/api/dialog/msg/sendtext", "/api/dialog/msg/", true},
+		{"sub-path other prefix", "/dootask", "/api/users/info", "/api/dialog/msg/", false},
+		{"query is ignored", "", "/api/dialog/msg/list?dialog_id=1", "/api/dialog/msg/", true},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ret":1,"msg":"","data":null}`))
+	}))
+	defer srv.Close()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig().WithRetryCount(0).
+				WithLimit(Limit{Prefix: tt.prefix, Rate: 0.001, Burst: 1, FailFast: true})
+			client := NewClientWithConfig(srv.URL+tt.base, cfg)
+
+			for i := 0; i < 2; i++ {
+				resp, err := client.Do(context.Background(), &Request{Method: "GET", Path: tt.path})
+				if i == 1 && tt.limited {
+					if !errors.Is(err, sdkerr.ErrRateLimited) {
+						t.Fatalf("second call = %v, want ErrRateLimited", err)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("call %d = %v", i+1, err)
+				}
+				resp.Body.Close()
+			}
+		})
+	}
+}
+
+func TestLimitMiddlewareMaxInFlight(t *testing.T) {
+	const maxInFlight = 3
+	var inFlight, peak atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ret":1,"msg":"","data":null}`))
+	}))
+	defer srv.Close()
+
+	client := NewClientWithConfig(srv.URL, DefaultConfig().WithLimit(Limit{MaxInFlight: maxInFlight}))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Do(context.Background(), &Request{Method: "GET", Path: "/api/users/info"})
+			if err != nil {
+				errs <- err
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("call failed: %v", err)
+	}
+	if got := peak.Load(); got > maxInFlight {
+		t.Errorf("peak in-flight requests = %d, want at most %d", got, maxInFlight)
+	}
+}
@@ -0,0 +1,149 @@
+package sdk
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	ihttp "github.com/xxyijixx/dootask-golang-sdk/internal/http"
+)
+
+// DefaultLogBodyLimit caps the number of bytes logged per body
+const DefaultLogBodyLimit = 4096
+
+// RequestIDHeader carries the request ID that correlates log lines with server logs
+const RequestIDHeader = "X-Request-Id"
+
+// DefaultRedactFields lists the body and query keys redacted from logs:
+// credentials and file contents
+var DefaultRedactFields = []string{"password", "oldpass", "newpass", "passwd", "token", "file", "content"}
+
+// LogOptions controls what LoggingMiddleware records
+type LogOptions struct {
+	Bodies       bool     // log request and response bodies
+	BodyLimit    int      // maximum logged bytes per body, DefaultLogBodyLimit when 0
+	RedactFields []string // keys to redact, DefaultRedactFields when nil
+}
+
+// LoggingMiddleware logs one structured record per attempt with method, path,
+// status, latency, request ID and the DooTask ret/msg. The Token header is
+// never logged and sensitive fields are redacted from URLs and bodies.
+func LoggingMiddleware(logger *slog.Logger, opts LogOptions) Middleware {
+	if opts.BodyLimit == 0 {
+		opts.BodyLimit = DefaultLogBodyLimit
+	}
+	if opts.RedactFields == nil {
+		opts.RedactFields = DefaultRedactFields
+	}
+
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			ctx := req.Context()
+			requestID := req.Header.Get(RequestIDHeader)
+			if requestID == "" {
+				requestID = newRequestID()
+				req.Header.Set(RequestIDHeader, requestID)
+			}
+
+			attrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("path", ihttp.RedactRequestURI(req.URL, opts.RedactFields)),
+				slog.String("request_id", requestID),
+			}
+			if opts.Bodies {
+				attrs = append(attrs, slog.Any("request_headers", ihttp.RedactHeaders(req.Header, "Token", "Authorization")))
+				if body := requestBody(req); len(body) > 0 {
+					attrs = append(attrs, slog.String("request_body", ihttp.RedactBody(body, opts.RedactFields, opts.BodyLimit)))
+				}
+			}
+
+			start := time.Now()
+			resp, err := next(req)
+			attrs = append(attrs, slog.Duration("latency", time.Since(start)))
+
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+				logger.LogAttrs(ctx, slog.LevelError, "dootask request failed", attrs...)
+				return nil, err
+			}
+
+			level := slog.LevelDebug
+			attrs = append(attrs, slog.Int("status", resp.StatusCode))
+			if resp.StatusCode >= 400 {
+				level = slog.LevelWarn
+			}
+
+			// Streamed downloads are left unread, even when they are JSON files
+			if isJSON(resp) && !isStream(ctx) {
+				body, readErr := bufferBody(resp)
+				var envelope struct {
+					Ret *int   `json:"ret"`
+					Msg string `json:"msg"`
+				}
+				if readErr == nil && json.Unmarshal(body, &envelope) == nil && envelope.Ret != nil {
+					attrs = append(attrs, slog.Int("ret", *envelope.Ret), slog.String("msg", envelope.Msg))
+					if *envelope.Ret != 1 {
+						level = slog.LevelWarn
+					}
+				}
+				if opts.Bodies && readErr == nil {
+					attrs = append(attrs, slog.String("response_body", ihttp.RedactBody(body, opts.RedactFields, opts.BodyLimit)))
+				}
+			}
+
+			logger.LogAttrs(ctx, level, "dootask request", attrs...)
+			return resp, nil
+		}
+	}
+}
+
+// debugLogger writes debug level text logs to stderr, used when Config.Debug
+// is set without a Logger
+func debugLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+// loggingMiddleware builds the logging middleware from the client
+// configuration, nil when logging is disabled
+func (c *Client) loggingMiddleware() Middleware {
+	logger := c.Config.Logger
+	if logger == nil {
+		if !c.Config.Debug {
+			return nil
+		}
+		logger = debugLogger()
+	}
+	return LoggingMiddleware(logger, LogOptions{
+		Bodies:       c.Config.LogBodies || c.Config.Debug,
+		RedactFields: c.Config.RedactFields,
+	})
+}
+
+func requestBody(req *http.Request) []byte {
+	if req.GetBody == nil {
+		return nil
+	}
+	rc, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer rc.Close()
+	body, _ := io.ReadAll(rc)
+	return body
+}
+
+func isJSON(resp *http.Response) bool {
+	return strings.Contains(resp.Header.Get("Content-Type"), "json")
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
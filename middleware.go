@@ -0,0 +1,87 @@
+package sdk
+
+import (
+	"net/http"
+
+	"github.com/xxyijixx/dootask-golang-sdk/sdkerr"
+)
+
+// RoundTripFunc sends a single HTTP request and returns its response
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper
+func (f RoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps a RoundTripFunc to inspect or mutate requests and responses.
+// Middlewares run once per attempt, so retried requests pass through them again.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// Chain composes middlewares so that the first one is the outermost
+func Chain(final RoundTripFunc, middlewares ...Middleware) RoundTripFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		final = middlewares[i](final)
+	}
+	return final
+}
+
+// HeaderMiddleware sets static headers on every request, overriding existing values
+func HeaderMiddleware(headers map[string]string) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			for key, value := range headers {
+				req.Header.Set(key, value)
+			}
+			return next(req)
+		}
+	}
+}
+
+// UserAgentMiddleware sets the User-Agent header when userAgent is not empty
+func UserAgentMiddleware(userAgent string) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if userAgent != "" {
+				req.Header.Set("User-Agent", userAgent)
+			}
+			return next(req)
+		}
+	}
+}
+
+// roundTrip sends req through the circuit breaker, limiters and built-in
+// middlewares followed by Config.Middlewares, ending at the underlying HTTP client.
+// Only failures of the HTTP client are reported as *TransportError; requests
+// rejected by the breaker, a limiter or a middleware keep their own error.
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	var middlewares []Middleware
+	if c.breaker != nil {
+		middlewares = append(middlewares, c.breaker.middleware)
+	}
+	if c.limits != nil {
+		middlewares = append(middlewares, c.limits)
+	}
+	middlewares = append(middlewares,
+		UserAgentMiddleware(c.Config.UserAgent),
+		c.authMiddleware(),
+	)
+	if len(c.headers) > 0 {
+		middlewares = append(middlewares, HeaderMiddleware(c.headers))
+	}
+	middlewares = append(middlewares, c.Config.Middlewares...)
+	if logging := c.loggingMiddleware(); logging != nil {
+		middlewares = append(middlewares, logging)
+	}
+
+	return Chain(c.send, middlewares...)(req)
+}
+
+// send performs req with the underlying HTTP client
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, &sdkerr.TransportError{Method: req.Method, URL: req.URL.String(), Err: err}
+	}
+	return resp, nil
+}
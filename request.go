@@ -0,0 +1,42 @@
+package sdk
+
+import (
+	"io"
+	"net/url"
+
+	"github.com/xxyijixx/dootask-golang-sdk/internal/core"
+)
+
+// Request types used by Client.Do and the service modules, see HTTPDoer
+type (
+	// HTTPDoer is the interface the service modules call; implement it to
+	// mock the client, e.g. project.New(mock)
+	HTTPDoer = core.HTTPDoer
+	// Request describes an API call
+	Request = core.Request
+	// Body is a request body with its own encoding
+	Body = core.Body
+	// File is a file part of a multipart body
+	File = core.File
+)
+
+// ErrBodyConsumed is returned when a streamed body that cannot be replayed is
+// sent again
+var ErrBodyConsumed = core.ErrBodyConsumed
+
+// BytesBody returns a body sending b with the given content type
+func BytesBody(contentType string, b []byte) Body {
+	return core.BytesBody(contentType, b)
+}
+
+// ReaderBody returns a body streaming r. Unless r is an io.Seeker the
+// request is not retried, since the body can only be sent once.
+func ReaderBody(contentType string, r io.Reader) Body {
+	return core.ReaderBody(contentType, r)
+}
+
+// MultipartBody returns a streamed multipart/form-data body with the given
+// fields and files
+func MultipartBody(fields url.Values, files ...File) Body {
+	return core.MultipartBody(fields, files...)
+}
@@ -0,0 +1,82 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/xxyijixx/dootask-golang-sdk/sdkerr"
+)
+
+// ResponseInfo describes the final response of an API call captured with
+// WithResponse. ResponseMeta holds the HTTP details and the raw body.
+type ResponseInfo struct {
+	ResponseMeta
+
+	Ret      *int   // DooTask ret code, nil when the body is not a DooTask envelope
+	Msg      string // DooTask msg, also on success
+	Duration time.Duration
+	Attempts int // number of attempts made, including retries
+}
+
+type (
+	responseCtxKey struct{}
+	streamCtxKey   struct{}
+)
+
+// WithResponse makes calls made with ctx fill info with the metadata of their
+// final response, also when the call fails. The body is buffered in memory,
+// except for downloads (Request.Stream) whose Body stays empty. Each call
+// overwrites info; use a separate ResponseInfo per concurrent call.
+//
+//	var info sdk.ResponseInfo
+//	list, err := client.Project.GetProjectListsWithContext(sdk.WithResponse(ctx, &info), req)
+//	log.Printf("status=%d msg=%q took %s", info.StatusCode, info.Msg, info.Duration)
+func WithResponse(ctx context.Context, info *ResponseInfo) context.Context {
+	return context.WithValue(ctx, responseCtxKey{}, info)
+}
+
+func responseInfoFromContext(ctx context.Context) *ResponseInfo {
+	info, _ := ctx.Value(responseCtxKey{}).(*ResponseInfo)
+	return info
+}
+
+// withStream marks calls whose response body is a raw stream, see Request.Stream
+func withStream(ctx context.Context) context.Context {
+	return context.WithValue(ctx, streamCtxKey{}, true)
+}
+
+func isStream(ctx context.Context) bool {
+	stream, _ := ctx.Value(streamCtxKey{}).(bool)
+	return stream
+}
+
+// captureResponse wraps call to record its outcome into info
+func captureResponse(info *ResponseInfo, call func(ctx context.Context) (*http.Response, int, error)) func(ctx context.Context) (*http.Response, int, error) {
+	return func(ctx context.Context) (*http.Response, int, error) {
+		start := time.Now()
+		resp, attempts, err := call(ctx)
+		*info = ResponseInfo{Duration: time.Since(start), Attempts: attempts}
+		if resp == nil {
+			return resp, attempts, err
+		}
+
+		// Streamed bodies are left unread, so only the headers are captured
+		var body []byte
+		if !isStream(ctx) {
+			body, _ = bufferBody(resp)
+		}
+		if meta := sdkerr.NewResponseMeta(resp, body); meta != nil {
+			info.ResponseMeta = *meta
+		}
+		var envelope struct {
+			Ret *int   `json:"ret"`
+			Msg string `json:"msg"`
+		}
+		if json.Unmarshal(body, &envelope) == nil {
+			info.Ret, info.Msg = envelope.Ret, envelope.Msg
+		}
+		return resp, attempts, err
+	}
+}
@@ -0,0 +1,259 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/xxyijixx/dootask-golang-sdk/sdkerr"
+)
+
+// Attempt describes the outcome of a single request attempt
+type Attempt struct {
+	Number   int            // 1-based attempt number
+	Request  *http.Request  // request sent for this attempt
+	Response *http.Response // nil when the transport failed
+	Ret      *int           // DooTask ret code, set when the response is a JSON envelope
+	Err      error          // transport error, if any
+	Delay    time.Duration  // wait before the next attempt, zero when no retry follows
+}
+
+// RetryPolicy decides whether a failed attempt should be retried
+type RetryPolicy interface {
+	ShouldRetry(a *Attempt) bool
+}
+
+// RetryPolicyFunc adapts a plain function to RetryPolicy
+type RetryPolicyFunc func(a *Attempt) bool
+
+// ShouldRetry implements RetryPolicy
+func (f RetryPolicyFunc) ShouldRetry(a *Attempt) bool {
+	return f(a)
+}
+
+// AttemptHook is invoked after every attempt, before any backoff wait
+type AttemptHook func(a *Attempt)
+
+// DefaultRetryPolicy retries network errors, 5xx and 429 responses and the
+// listed DooTask ret codes. Requests not marked idempotent (see
+// Request.Idempotent and WithIdempotent) are only retried when the server
+// provably did not process them: dial failures and 429 responses.
+type DefaultRetryPolicy struct {
+	RetryRets []int // DooTask ret codes treated as transient
+}
+
+// ShouldRetry implements RetryPolicy
+func (p DefaultRetryPolicy) ShouldRetry(a *Attempt) bool {
+	idempotent := isIdempotent(a.Request)
+
+	if a.Err != nil {
+		if errors.Is(a.Err, context.Canceled) || errors.Is(a.Err, context.DeadlineExceeded) ||
+			errors.Is(a.Err, sdkerr.ErrRateLimited) || errors.Is(a.Err, sdkerr.ErrUnauthorized) ||
+			errors.Is(a.Err, sdkerr.ErrCircuitOpen) {
+			return false
+		}
+		if !idempotent {
+			return isDialError(a.Err)
+		}
+		return true
+	}
+
+	if a.Response == nil {
+		return false
+	}
+
+	switch code := a.Response.StatusCode; {
+	case code == http.StatusTooManyRequests:
+		return true
+	case code >= 500:
+		return idempotent
+	}
+
+	if a.Ret != nil && idempotent {
+		for _, ret := range p.RetryRets {
+			if *a.Ret == ret {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ExponentialBackoff returns the wait before the given retry (1-based) using
+// exponential growth from minWait capped at maxWait, randomised between half
+// of minWait and the current ceiling to spread out concurrent retries
+func ExponentialBackoff(minWait, maxWait time.Duration, retry int) time.Duration {
+	if minWait <= 0 {
+		return 0
+	}
+	if maxWait < minWait {
+		maxWait = minWait
+	}
+
+	ceiling := minWait
+	for i := 1; i < retry && ceiling < maxWait; i++ {
+		ceiling *= 2
+	}
+	if ceiling > maxWait {
+		ceiling = maxWait
+	}
+
+	return minWait/2 + rand.N(ceiling-minWait/2+1)
+}
+
+type retryCtxKey int
+
+const (
+	idempotentKey retryCtxKey = iota
+	noRetryKey
+)
+
+// WithIdempotent marks requests made with ctx as safe to retry. The service
+// methods mark their read-only endpoints themselves; use it for raw calls
+// made with DoRequest or Do.
+func WithIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey, true)
+}
+
+// WithoutRetry disables retries for requests made with ctx
+func WithoutRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetryKey, true)
+}
+
+// isIdempotent reports whether req was marked safe to repeat. The method is
+// deliberately ignored: DooTask creates, sends and deletes through GET.
+func isIdempotent(req *http.Request) bool {
+	if req == nil {
+		return false
+	}
+	v, _ := req.Context().Value(idempotentKey).(bool)
+	return v
+}
+
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return opErr.Op == "dial"
+	}
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
+
+// retryAfter parses a Retry-After header expressed in seconds or as an HTTP date
+func retryAfter(resp *http.Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// peekRet reads the DooTask ret code from a JSON response while leaving the
+// body readable for the caller. Streamed responses (see Request.Stream) are
+// never read, even when they are JSON files.
+func peekRet(ctx context.Context, resp *http.Response) *int {
+	if resp == nil || resp.Body == nil || !isJSON(resp) || isStream(ctx) {
+		return nil
+	}
+
+	body, err := bufferBody(resp)
+	if err != nil {
+		return nil
+	}
+
+	var envelope struct {
+		Ret *int `json:"ret"`
+	}
+	if json.Unmarshal(body, &envelope) != nil {
+		return nil
+	}
+	return envelope.Ret
+}
+
+// bufferBody reads the whole response body and replaces it with an in-memory
+// copy so later readers see the same bytes
+func bufferBody(resp *http.Response) ([]byte, error) {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return body, err
+}
+
+// doWithRetry sends the request built by newReq, retrying according to the
+// configured policy, and reports the number of attempts made. newReq is
+// called once per attempt so the body can be replayed.
+func (c *Client) doWithRetry(ctx context.Context, newReq func(ctx context.Context) (*http.Request, error)) (*http.Response, int, error) {
+	maxRetries := c.Config.RetryCount
+	if noRetry, _ := ctx.Value(noRetryKey).(bool); noRetry || maxRetries < 0 {
+		maxRetries = 0
+	}
+
+	policy := c.Config.RetryPolicy
+	if policy == nil {
+		policy = DefaultRetryPolicy{}
+	}
+
+	for n := 1; ; n++ {
+		req, err := newReq(ctx)
+		if err != nil {
+			return nil, n, err
+		}
+
+		resp, err := c.roundTrip(req)
+		attempt := &Attempt{Number: n, Request: req, Response: resp, Err: err}
+		if err == nil && maxRetries > 0 {
+			attempt.Ret = peekRet(ctx, resp)
+		}
+
+		retry := n <= maxRetries && policy.ShouldRetry(attempt)
+		if retry {
+			attempt.Delay = ExponentialBackoff(c.Config.RetryWaitMin, c.Config.RetryWaitMax, n)
+			if wait := retryAfter(resp); wait > attempt.Delay {
+				attempt.Delay = wait
+			}
+		}
+
+		if c.Config.OnAttempt != nil {
+			c.Config.OnAttempt(attempt)
+		}
+
+		if !retry {
+			if err != nil {
+				return nil, n, err
+			}
+			return resp, n, nil
+		}
+
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(attempt.Delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, n, &sdkerr.TransportError{Method: req.Method, URL: req.URL.String(), Err: ctx.Err()}
+		case <-timer.C:
+		}
+	}
+}
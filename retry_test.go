@@ -0,0 +1,331 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/xxyijixx/dootask-golang-sdk/sdkerr"
+)
+
+func newAttempt(t *testing.T, idempotent bool, status int, err error, ret *int) *Attempt {
+	t.Helper()
+	ctx := context.Background()
+	if idempotent {
+		ctx = WithIdempotent(ctx)
+	}
+	req, reqErr := http.NewRequestWithContext(ctx, http.MethodGet, "http://dootask.test/api/x", nil)
+	if reqErr != nil {
+		t.Fatal(reqErr)
+	}
+	a := &Attempt{Number: 1, Request: req, Err: err, Ret: ret}
+	if err == nil {
+		a.Response = &http.Response{StatusCode: status, Header: http.Header{}}
+	}
+	return a
+}
+
+func intPtr(v int) *int { return &v }
+
+func TestDefaultRetryPolicy(t *testing.T) {
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	readErr := &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset")}
+
+	tests := []struct {
+		name       string
+		idempotent bool
+		status     int
+		err        error
+		ret        *int
+		want       bool
+	}{
+		{"idempotent 502", true, 502, nil, nil, true},
+		{"write 502", false, 502, nil, nil, false},
+		{"idempotent 200", true, 200, nil, nil, false},
+		{"write 429", false, 429, nil, nil, true},
+		{"idempotent 404", true, 404, nil, nil, false},
+		{"write dial error", false, 0, dialErr, nil, true},
+		{"write read error", false, 0, readErr, nil, false},
+		{"idempotent read error", true, 0, readErr, nil, true},
+		{"wrapped dial error", false, 0, &sdkerr.TransportError{Err: dialErr}, nil, true},
+		{"canceled", true, 0, context.Canceled, nil, false},
+		{"deadline", true, 0, fmt.Errorf("call: %w", context.DeadlineExceeded), nil, false},
+		{"rate limited", true, 0, sdkerr.ErrRateLimited, nil, false},
+		{"circuit open", true, 0, &sdkerr.CircuitOpenError{}, nil, false},
+		{"unauthorized", true, 0, sdkerr.ErrUnauthorized, nil, false},
+		{"idempotent listed ret", true, 200, nil, intPtr(-2), true},
+		{"write listed ret", false, 200, nil, intPtr(-2), false},
+		{"idempotent other ret", true, 200, nil, intPtr(0), false},
+	}
+
+	policy := DefaultRetryPolicy{RetryRets: []int{-2}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newAttempt(t, tt.idempotent, tt.status, tt.err, tt.ret)
+			if got := policy.ShouldRetry(a); got != tt.want {
+				t.Errorf("ShouldRetry = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultRetryPolicyIgnoresMethod(t *testing.T) {
+	for _, method := range []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodPost} {
+		req, _ := http.NewRequest(method, "http://dootask.test/api/report/store", nil)
+		a := &Attempt{Number: 1, Request: req, Response: &http.Response{StatusCode: 503}}
+		if (DefaultRetryPolicy{}).ShouldRetry(a) {
+			t.Errorf("%s 503 without idempotent mark was retried", method)
+		}
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		min, max time.Duration
+		retry    int
+		low      time.Duration
+		high     time.Duration
+	}{
+		{"disabled", 0, time.Second, 1, 0, 0},
+		{"first retry", 100 * time.Millisecond, time.Second, 1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{"third retry", 100 * time.Millisecond, time.Second, 3, 50 * time.Millisecond, 400 * time.Millisecond},
+		{"capped", 100 * time.Millisecond, 300 * time.Millisecond, 10, 50 * time.Millisecond, 300 * time.Millisecond},
+		{"max below min", 200 * time.Millisecond, 50 * time.Millisecond, 4, 100 * time.Millisecond, 200 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 100 {
+				got := ExponentialBackoff(tt.min, tt.max, tt.retry)
+				if got < tt.low || got > tt.high {
+					t.Fatalf("ExponentialBackoff = %s, want within [%s, %s]", got, tt.low, tt.high)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		low    time.Duration
+		high   time.Duration
+	}{
+		{"missing", "", 0, 0},
+		{"seconds", "3", 3 * time.Second, 3 * time.Second},
+		{"zero", "0", 0, 0},
+		{"negative", "-5", 0, 0},
+		{"garbage", "soon", 0, 0},
+		{"future date", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat), 59 * time.Minute, time.Hour},
+		{"past date", time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tt.header != "" {
+				resp.Header.Set("Retry-After", tt.header)
+			}
+			if got := retryAfter(resp); got < tt.low || got > tt.high {
+				t.Errorf("retryAfter(%q) = %s, want within [%s, %s]", tt.header, got, tt.low, tt.high)
+			}
+		})
+	}
+
+	if got := retryAfter(nil); got != 0 {
+		t.Errorf("retryAfter(nil) = %s, want 0", got)
+	}
+}
+
+func TestPeekRet(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		stream      bool
+		want        *int
+	}{
+		{"envelope", "application/json", `{"ret":1,"data":{}}`, false, intPtr(1)},
+		{"error envelope", "application/json; charset=utf-8", `{"ret":-1,"msg":"x"}`, false, intPtr(-1)},
+		{"no ret", "application/json", `{"data":{}}`, false, nil},
+		{"invalid json", "application/json", `{`, false, nil},
+		{"not json", "text/plain", `{"ret":1}`, false, nil},
+		{"stream", "application/json", `{"ret":1}`, true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.stream {
+				ctx = withStream(ctx)
+			}
+			resp := &http.Response{
+				Header: http.Header{"Content-Type": {tt.contentType}},
+				Body:   io.NopCloser(strings.NewReader(tt.body)),
+			}
+
+			got := peekRet(ctx, resp)
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("peekRet = %v, want %v", got, tt.want)
+			}
+			if body, _ := io.ReadAll(resp.Body); string(body) != tt.body {
+				t.Errorf("body after peek = %q, want %q", body, tt.body)
+			}
+		})
+	}
+}
+
+func TestDoWithRetry(t *testing.T) {
+	tests := []struct {
+		name       string
+		statuses   []int
+		bodies     []string
+		idempotent bool
+		noRetry    bool
+		retryRets  []int
+		wantHits   int
+		wantStatus int
+	}{
+		{"recovers after 5xx", []int{502, 503, 200}, nil, true, false, nil, 3, 200},
+		{"gives up after RetryCount", []int{502, 502, 502, 502, 502}, nil, true, false, nil, 4, 502},
+		{"write not retried on 5xx", []int{502, 200}, nil, false, false, nil, 1, 502},
+		{"write retried on 429", []int{429, 200}, nil, false, false, nil, 2, 200},
+		{"WithoutRetry", []int{502, 200}, nil, true, true, nil, 1, 502},
+		{"listed ret", []int{200, 200}, []string{`{"ret":-2}`, `{"ret":1}`}, true, false, []int{-2}, 2, 200},
+		{"success", []int{200}, []string{`{"ret":1}`}, true, false, nil, 1, 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(hits.Add(1)) - 1
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.statuses[min(n, len(tt.statuses)-1)])
+				if len(tt.bodies) > 0 {
+					io.WriteString(w, tt.bodies[min(n, len(tt.bodies)-1)])
+				}
+			}))
+			defer srv.Close()
+
+			config := DefaultConfig().
+				WithRetryBackoff(time.Millisecond, time.Millisecond).
+				WithRetryPolicy(DefaultRetryPolicy{RetryRets: tt.retryRets})
+			client := NewClientWithConfig(srv.URL, config)
+
+			ctx := context.Background()
+			if tt.noRetry {
+				ctx = WithoutRetry(ctx)
+			}
+			resp, err := client.Do(ctx, &Request{Method: http.MethodGet, Path: "/api/x", Idempotent: tt.idempotent})
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			if got := int(hits.Load()); got != tt.wantHits {
+				t.Errorf("server hits = %d, want %d", got, tt.wantHits)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestDoWithRetryHonoursRetryAfter(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		io.WriteString(w, `{"ret":1}`)
+	}))
+	defer srv.Close()
+
+	var delays []time.Duration
+	config := DefaultConfig().
+		WithRetryBackoff(time.Millisecond, time.Millisecond).
+		WithAttemptHook(func(a *Attempt) { delays = append(delays, a.Delay) })
+	client := NewClientWithConfig(srv.URL, config)
+
+	start := time.Now()
+	resp, err := client.Do(context.Background(), &Request{Method: http.MethodGet, Path: "/api/x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if len(delays) != 2 || delays[0] != time.Second || delays[1] != 0 {
+		t.Errorf("attempt delays = %v, want [1s 0s]", delays)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %s, want at least 1s", elapsed)
+	}
+}
+
+func TestDoWithRetryCancelledDuringBackoff(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	client := NewClientWithConfig(srv.URL, DefaultConfig().WithRetryBackoff(time.Hour, time.Hour))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.Do(ctx, &Request{Method: http.MethodGet, Path: "/api/x", Idempotent: true})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestDoWithRetryNonReplayableBody(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	client := NewClientWithConfig(srv.URL, DefaultConfig().WithRetryBackoff(time.Millisecond, time.Millisecond))
+	body := ReaderBody("text/plain", io.MultiReader(strings.NewReader("once")))
+	resp, err := client.Do(context.Background(), &Request{Method: http.MethodPost, Path: "/api/x", Body: body})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if got := hits.Load(); got != 1 {
+		t.Errorf("server hits = %d, want 1", got)
+	}
+}
+
+func TestDoWithRetryTransportError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := srv.URL
+	srv.Close()
+
+	client := NewClientWithConfig(url, DefaultConfig().WithRetryCount(0))
+	_, err := client.Do(context.Background(), &Request{Method: http.MethodGet, Path: "/api/x"})
+
+	var transportErr *sdkerr.TransportError
+	if !errors.As(err, &transportErr) {
+		t.Fatalf("err = %v, want *TransportError", err)
+	}
+	if transportErr.Method != http.MethodGet || !strings.HasSuffix(transportErr.URL, "/api/x") {
+		t.Errorf("TransportError = %+v", transportErr)
+	}
+}
@@ -0,0 +1,205 @@
+// Package sdkerr defines the errors returned by the DooTask SDK.
+//
+// Service methods wrap these with %w, so callers can inspect them with
+// errors.As and match categories with errors.Is against the sentinel values:
+//
+//	var apiErr sdkerr.APIError
+//	if errors.As(err, &apiErr) {
+//		log.Printf("ret=%d msg=%s", apiErr.Ret, apiErr.Msg)
+//	}
+//	if errors.Is(err, sdkerr.ErrUnauthorized) {
+//		// re-login
+//	}
+package sdkerr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RetUnauthorized is the ret code DooTask returns when the token is missing or expired
+const RetUnauthorized = -1
+
+// Sentinel errors matched with errors.Is
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrServer       = errors.New("server error")
+	ErrValidation   = errors.New("validation failed")
+	ErrCircuitOpen  = errors.New("circuit open")
+)
+
+// ResponseMeta holds the raw response details attached to errors for diagnostics
+type ResponseMeta struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// NewResponseMeta captures metadata from resp together with its already read body
+func NewResponseMeta(resp *http.Response, body []byte) *ResponseMeta {
+	if resp == nil {
+		return nil
+	}
+	meta := &ResponseMeta{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+	}
+	if resp.Request != nil {
+		meta.Method = resp.Request.Method
+		if resp.Request.URL != nil {
+			meta.URL = resp.Request.URL.String()
+		}
+	}
+	return meta
+}
+
+// TransportError reports a failure to send the request or read the response
+type TransportError struct {
+	Method string
+	URL    string
+	Err    error
+}
+
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("transport error: %v", e.Err)
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// AuthError reports a failure to obtain a token from the token source
+type AuthError struct {
+	Err error
+}
+
+func (e *AuthError) Error() string {
+	return fmt.Sprintf("token source: %v", e.Err)
+}
+
+func (e *AuthError) Unwrap() error {
+	return e.Err
+}
+
+// Is matches ErrUnauthorized
+func (e *AuthError) Is(target error) bool {
+	return target == ErrUnauthorized
+}
+
+// CircuitOpenError reports a request rejected without being sent because the
+// circuit breaker is open
+type CircuitOpenError struct {
+	RetryAfter time.Duration // time left until the breaker lets a probe through
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit open, retry after %s", e.RetryAfter.Round(time.Millisecond))
+}
+
+// Is matches ErrCircuitOpen
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// HTTPError reports a response with a 4xx or 5xx status code
+type HTTPError struct {
+	StatusCode int
+	Ret        int    // ret code when the body is a DooTask envelope
+	Msg        string // msg from the envelope, or the raw body otherwise
+	Response   *ResponseMeta
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Msg)
+}
+
+// Is maps the status code onto the sentinel errors
+func (e *HTTPError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.Ret == RetUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= 500
+	}
+	return false
+}
+
+// APIError represents a DooTask API error, i.e. a response whose ret is not 1
+type APIError struct {
+	Ret      int             `json:"ret"`
+	Msg      string          `json:"msg"`
+	Data     json.RawMessage `json:"data,omitempty"`
+	Response *ResponseMeta   `json:"-"`
+}
+
+func (e APIError) Error() string {
+	return fmt.Sprintf("API error [%d]: %s", e.Ret, e.Msg)
+}
+
+// forbiddenMsgs are the messages DooTask answers with when the user lacks
+// the rights for an operation, e.g. a non-admin calling an admin endpoint
+var forbiddenMsgs = []string{"权限不足", "仅限管理员", "无权限"}
+
+// Is reports ErrUnauthorized for DooTask's expired-token ret code and
+// ErrForbidden for its permission-denied messages
+func (e APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.Ret == RetUnauthorized
+	case ErrForbidden:
+		for _, msg := range forbiddenMsgs {
+			if strings.Contains(e.Msg, msg) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// DecodeError reports a response body that could not be decoded
+type DecodeError struct {
+	Err      error
+	Response *ResponseMeta
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("failed to decode response: %v", e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError reports invalid request parameters detected before sending
+type ValidationError struct {
+	Fields []string
+	Msg    string
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Fields) == 0 {
+		return e.Msg
+	}
+	return fmt.Sprintf("%s: %s", e.Msg, strings.Join(e.Fields, ", "))
+}
+
+// Is matches ErrValidation
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
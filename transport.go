@@ -0,0 +1,158 @@
+package sdk
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/xxyijixx/dootask-golang-sdk/sdkerr"
+)
+
+// Default connection pool settings, matching http.DefaultTransport
+const (
+	DefaultMaxIdleConns    = 100
+	DefaultIdleConnTimeout = 90 * time.Second
+	DefaultKeepAlive       = 30 * time.Second
+)
+
+// newHTTPClient builds the HTTP client described by config: config.HTTPClient
+// as-is, config.Transport wrapped with the timeout, or a transport built from
+// the proxy, TLS and connection pool settings
+func newHTTPClient(config *Config) (*http.Client, error) {
+	if config.HTTPClient != nil {
+		return config.HTTPClient, nil
+	}
+	if config.Transport != nil {
+		return &http.Client{Timeout: config.Timeout, Transport: config.Transport}, nil
+	}
+
+	transport, err := newTransport(config)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Timeout: config.Timeout, Transport: transport}, nil
+}
+
+func newTransport(config *Config) (*http.Transport, error) {
+	tlsConfig, err := newTLSConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy := http.ProxyFromEnvironment
+	if config.ProxyURL != "" {
+		u, err := parseProxyURL(config.ProxyURL)
+		if err != nil {
+			return nil, err
+		}
+		proxy = http.ProxyURL(u)
+	}
+
+	keepAlive := config.KeepAlive
+	if keepAlive == 0 {
+		keepAlive = DefaultKeepAlive
+	}
+	maxIdle := config.MaxIdleConns
+	if maxIdle == 0 {
+		maxIdle = DefaultMaxIdleConns
+	}
+	idleTimeout := config.IdleConnTimeout
+	if idleTimeout == 0 {
+		idleTimeout = DefaultIdleConnTimeout
+	}
+
+	return &http.Transport{
+		Proxy: proxy,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: keepAlive,
+		}).DialContext,
+		TLSClientConfig:       tlsConfig,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          maxIdle,
+		MaxIdleConnsPerHost:   config.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       config.MaxConnsPerHost,
+		IdleConnTimeout:       idleTimeout,
+		DisableKeepAlives:     config.DisableKeepAlives,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}, nil
+}
+
+func newTLSConfig(config *Config) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: config.Insecure,
+	}
+
+	if len(config.RootCAPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(config.RootCAPEM) {
+			return nil, errors.New("root CA PEM contains no certificates")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if len(config.ClientCertPEM) > 0 || len(config.ClientKeyPEM) > 0 {
+		cert, err := tls.X509KeyPair(config.ClientCertPEM, config.ClientKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+func parseProxyURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("proxy_url: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("proxy_url: unsupported scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, errors.New("proxy_url: missing host")
+	}
+	return u, nil
+}
+
+// validateTransport reports problems in the transport settings
+func (c *Config) validateTransport() []string {
+	if c.HTTPClient != nil || c.Transport != nil {
+		return nil
+	}
+
+	var problems []string
+	if c.ProxyURL != "" {
+		if _, err := parseProxyURL(c.ProxyURL); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	if _, err := newTLSConfig(c); err != nil {
+		problems = append(problems, "tls: "+err.Error())
+	}
+	if c.MaxIdleConns < 0 || c.MaxIdleConnsPerHost < 0 || c.MaxConnsPerHost < 0 {
+		problems = append(problems, "max_conns: must not be negative")
+	}
+	if c.IdleConnTimeout < 0 {
+		problems = append(problems, "idle_conn_timeout: must not be negative")
+	}
+	return problems
+}
+
+// configError is returned by every request of a client whose transport
+// could not be built from its Config
+func configError(err error) error {
+	return &sdkerr.ValidationError{Fields: []string{err.Error()}, Msg: "invalid transport config"}
+}
@@ -1 +1,148 @@
 package types
+
+// ==================== 审批流程定义 ====================
+
+// 审批实例状态，用于 ApproveInstance.State
+const (
+	ApproveStateRunning   = 1 // 审批中
+	ApproveStatePassed    = 2 // 已通过
+	ApproveStateRejected  = 3 // 已拒绝
+	ApproveStateWithdrawn = 4 // 已撤回
+)
+
+// 流程节点类型，用于 ApproveNode.Type
+const (
+	ApproveNodeStart    = "start"    // 发起人
+	ApproveNodeApprover = "approver" // 审批人
+	ApproveNodeNotifier = "notifier" // 抄送人
+)
+
+// ApproveNodeUser 节点上的审批人或抄送人
+type ApproveNodeUser struct {
+	TargetID int    `json:"targetId"` // 会员ID
+	Type     string `json:"type"`     // 类型: user(指定会员), leader(部门负责人)
+	Name     string `json:"name"`     // 显示名称
+}
+
+// ApproveNode 流程节点，后续节点通过 ChildNode 串联
+type ApproveNode struct {
+	Name         string            `json:"name"`                   // 节点名称，如 "直属主管审批"
+	Type         string            `json:"type"`                   // 节点类型，见 ApproveNode* 常量
+	NodeID       string            `json:"nodeId"`                 // 节点ID，流程内唯一
+	PrevID       string            `json:"prevId,omitempty"`       // 上一节点ID
+	ExamineMode  string            `json:"examineMode,omitempty"`  // 多人审批方式: and(会签，需全部同意), or(或签，一人同意即可)
+	NodeUserList []ApproveNodeUser `json:"nodeUserList,omitempty"` // 审批人或抄送人
+	ChildNode    *ApproveNode      `json:"childNode,omitempty"`    // 下一节点
+}
+
+// Nodes 返回从 n 开始按顺序串联的全部节点
+func (n *ApproveNode) Nodes() []*ApproveNode {
+	var nodes []*ApproveNode
+	for node := n; node != nil; node = node.ChildNode {
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// ApproveProcDef 审批流程定义，如请假、报销
+type ApproveProcDef struct {
+	ID         int          `json:"id,omitempty"`
+	Name       string       `json:"name"`                  // 流程名称，发起审批时以此指定流程
+	Version    int          `json:"version,omitempty"`     // 版本，每次保存递增
+	Resource   *ApproveNode `json:"resource"`              // 流程节点，从发起人节点开始
+	UserID     int          `json:"userid,omitempty"`      // 创建人ID
+	Username   string       `json:"username,omitempty"`    // 创建人
+	DeployTime *DateTime    `json:"deploy_time,omitempty"` // 发布时间
+}
+
+// ApproveProcDefListRequest 01. 获取流程定义列表
+type ApproveProcDefListRequest struct {
+	Name string `json:"name,omitempty"` // 按流程名称筛选
+}
+
+// ApproveProcDefDelRequest 03. 删除流程定义
+type ApproveProcDefDelRequest struct {
+	ID int `json:"id"` // 流程定义ID
+}
+
+// ==================== 审批实例 ====================
+
+// ApproveVar 审批表单内容
+type ApproveVar struct {
+	Type        string `json:"type"`                  // 类型，如请假的 "年假"、报销的 "差旅费"
+	StartTime   string `json:"startTime,omitempty"`   // 开始时间，格式: YYYY-MM-DD HH:mm:ss
+	EndTime     string `json:"endTime,omitempty"`     // 结束时间，格式: YYYY-MM-DD HH:mm:ss
+	Description string `json:"description,omitempty"` // 事由
+	Other       string `json:"other,omitempty"`       // 其他内容，如报销金额、附件地址
+}
+
+// ApproveInstance 审批实例，即一次发起的审批
+type ApproveInstance struct {
+	ID            int              `json:"id"`
+	ProcDefID     int              `json:"proc_def_id"`          // 流程定义ID
+	ProcDefName   string           `json:"proc_def_name"`        // 流程名称
+	Title         string           `json:"title"`                // 标题
+	DepartmentID  int              `json:"department_id"`        // 发起人所在部门ID
+	StartUserID   int              `json:"start_user_id"`        // 发起人ID
+	StartUserName string           `json:"start_user_name"`      // 发起人
+	NodeID        string           `json:"node_id"`              // 当前节点ID
+	TaskID        int              `json:"task_id"`              // 当前待审批任务ID，审批结束后为0
+	Candidates    []int            `json:"candidates"`           // 当前节点尚未审批的审批人ID
+	State         int              `json:"state"`                // 状态，见 ApproveState* 常量
+	IsFinished    bool             `json:"is_finished"`          // 是否已结束
+	Var           ApproveVar       `json:"var"`                  // 表单内容
+	StartTime     *DateTime        `json:"start_time,omitempty"` // 发起时间
+	EndTime       *DateTime        `json:"end_time,omitempty"`   // 结束时间
+	History       []ApproveHistory `json:"history,omitempty"`    // 审批记录，仅详情返回
+	Resource      *ApproveNode     `json:"resource,omitempty"`   // 流程节点，仅详情返回
+}
+
+// ApproveHistory 审批记录
+type ApproveHistory struct {
+	ID         int       `json:"id"`
+	ProcInstID int       `json:"proc_inst_id"`         // 审批实例ID
+	TaskID     int       `json:"task_id"`              // 审批任务ID
+	NodeID     string    `json:"node_id"`              // 节点ID
+	UserID     int       `json:"userid"`               // 操作人ID
+	Username   string    `json:"username"`             // 操作人
+	Action     string    `json:"action"`               // 操作: start(发起), pass(同意), refuse(拒绝), withdraw(撤回)
+	Comment    string    `json:"comment"`              // 审批意见
+	CreatedAt  *DateTime `json:"created_at,omitempty"` // 操作时间
+}
+
+// ApproveStartRequest 04. 发起审批
+type ApproveStartRequest struct {
+	ProcName     string     `json:"proc_name"`               // 流程名称
+	DepartmentID int        `json:"department_id,omitempty"` // 发起人所在部门ID
+	Var          ApproveVar `json:"var"`                     // 表单内容
+}
+
+// ApproveListRequest 05. 审批列表（待我审批、我发起的、我已审批）
+type ApproveListRequest struct {
+	ProcName string `json:"proc_name,omitempty"` // 按流程名称筛选
+	State    int    `json:"state,omitempty"`     // 按状态筛选，见 ApproveState* 常量
+	Page     int    `json:"page,omitempty"`      // 页码
+	Pagesize int    `json:"pagesize,omitempty"`  // 每页数量
+}
+
+type ApproveListResponse = Page[ApproveInstance]
+
+// ApproveDetailRequest 08. 审批详情
+type ApproveDetailRequest struct {
+	ID int `json:"id"` // 审批实例ID
+}
+
+// ==================== 审批操作 ====================
+
+// ApproveTaskCompleteRequest 09. 审批（同意或拒绝）
+type ApproveTaskCompleteRequest struct {
+	TaskID  int    `json:"task_id"`           // 审批任务ID
+	Pass    string `json:"pass"`              // 是否同意: true, false
+	Comment string `json:"comment,omitempty"` // 审批意见
+}
+
+// ApproveWithdrawRequest 10. 撤回审批
+type ApproveWithdrawRequest struct {
+	TaskID     int `json:"task_id"`      // 当前审批任务ID
+	ProcInstID int `json:"proc_inst_id"` // 审批实例ID
+}
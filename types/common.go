@@ -2,6 +2,8 @@ package types
 
 import (
 	"encoding/json"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -43,3 +45,67 @@ func (ct *DateTime) UnmarshalJSON(data []byte) error {
 func (ct DateTime) MarshalJSON() ([]byte, error) {
 	return json.Marshal(ct.Time.Format(time.DateTime))
 }
+
+// Page 分页数据，对应 DooTask（Laravel）分页器返回的结构
+type Page[T any] struct {
+	CurrentPage  int        `json:"current_page"`   // 当前页码
+	Data         []T        `json:"data"`           // 当前页数据
+	FirstPageUrl *string    `json:"first_page_url"` // 第一页地址
+	From         *int       `json:"from"`           // 当前页第一条的序号，无数据时为空
+	LastPage     int        `json:"last_page"`      // 最后一页页码
+	LastPageUrl  *string    `json:"last_page_url"`  // 最后一页地址
+	Links        []PageLink `json:"links"`          // 分页链接
+	NextPageUrl  *string    `json:"next_page_url"`  // 下一页地址，没有下一页时为空
+	Path         string     `json:"path"`           // 接口地址
+	PerPage      int        `json:"per_page"`       // 每页数量
+	PrevPageUrl  *string    `json:"prev_page_url"`  // 上一页地址，没有上一页时为空
+	To           *int       `json:"to"`             // 当前页最后一条的序号，无数据时为空
+	Total        int        `json:"total"`          // 总数
+}
+
+// PageLink 分页链接
+type PageLink struct {
+	URL    *string `json:"url"`
+	Label  string  `json:"label"`
+	Active bool    `json:"active"`
+}
+
+// HasNext 是否还有下一页
+func (p *Page[T]) HasNext() bool {
+	return p.CurrentPage < p.LastPage || (p.LastPage == 0 && p.NextPageUrl != nil)
+}
+
+// NextPage 返回下一页的页码，没有下一页时返回 0
+func (p *Page[T]) NextPage() int {
+	if !p.HasNext() {
+		return 0
+	}
+	return p.CurrentPage + 1
+}
+
+// NumberString 数值类字段，DooTask 可能以数字、字符串或空字符串返回，统一保存为字符串
+type NumberString string
+
+// UnmarshalJSON 同时接受 JSON 数字和字符串
+func (n *NumberString) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*n = NumberString(s)
+		return nil
+	}
+	var num json.Number
+	if err := json.Unmarshal(data, &num); err != nil {
+		return err
+	}
+	*n = NumberString(num)
+	return nil
+}
+
+// Int 返回整数值，为空或无法解析时返回 0
+func (n NumberString) Int() int {
+	v, _ := strconv.Atoi(strings.TrimSpace(string(n)))
+	return v
+}
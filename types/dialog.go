@@ -173,20 +173,8 @@ type DialogListsRequest struct {
 }
 
 type DialogListsResponse struct {
-	CurrentPage  int           `json:"current_page"`
-	Data         []DialogItem  `json:"data"`
-	FirstPageUrl *string       `json:"first_page_url"`
-	From         *int          `json:"from"`
-	LastPage     int           `json:"last_page"`
-	LastPageUrl  *string       `json:"last_page_url"`
-	Links        []interface{} `json:"links"`
-	NextPageUrl  *string       `json:"next_page_url"`
-	Path         string        `json:"path"`
-	PerPage      int           `json:"per_page"`
-	PrevPageUrl  *string       `json:"prev_page_url"`
-	To           *int          `json:"to"`
-	Total        int           `json:"total"`
-	DeletedID    []int         `json:"deleted_id,omitempty"`
+	Page[DialogItem]
+	DeletedID []int `json:"deleted_id,omitempty"` // 已删除的对话ID
 }
 
 // 2. 搜索会话
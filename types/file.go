@@ -92,6 +92,9 @@ type FileContentHistoryRequest struct {
 	PageSize *int `json:"pagesize,omitempty"` // 每页显示数量，默认20，最大100
 }
 
+// FileContentHistoryResponse represents the history response
+type FileContentHistoryResponse = Page[FileHistory]
+
 // FileContentRestoreRequest represents the restore request
 type FileContentRestoreRequest struct {
 	ID        int `json:"id"`         // 文件ID
@@ -10,9 +10,7 @@ type ProjectListsRequest struct {
 	Pagesize int    `json:"pagesize,omitempty"` // 每页数量
 }
 
-type ProjectListsResponse struct {
-	Data []ProjectInfo `json:"data"`
-}
+type ProjectListsResponse = Page[ProjectInfo]
 
 type ProjectInfo struct {
 	ID            int       `json:"id"`
@@ -261,9 +259,7 @@ type ProjectTaskListsRequest struct {
 	Pagesize  int    `json:"pagesize,omitempty"`   // 每页数量
 }
 
-type ProjectTaskListsResponse struct {
-	Data []TaskInfo `json:"data"`
-}
+type ProjectTaskListsResponse = Page[TaskInfo]
 
 type TaskInfo struct {
 	ID         int        `json:"id"`
@@ -581,9 +577,7 @@ type ProjectLogListsRequest struct {
 	Pagesize  int `json:"pagesize,omitempty"`   // 每页数量
 }
 
-type ProjectLogListsResponse struct {
-	Data []LogInfo `json:"data"`
-}
+type ProjectLogListsResponse = Page[LogInfo]
 
 type LogInfo struct {
 	ID        int      `json:"id"`
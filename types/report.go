@@ -9,9 +9,7 @@ type ReportMyRequest struct {
 	Page   int    `json:"page,omitempty"`   // 页码
 }
 
-type ReportMyResponse struct {
-	Data []ReportInfo `json:"data"`
-}
+type ReportMyResponse = Page[ReportInfo]
 
 // ReportReceiveRequest 02. 我接收的汇报
 type ReportReceiveRequest struct {
@@ -21,9 +19,7 @@ type ReportReceiveRequest struct {
 	Page   int    `json:"page,omitempty"`   // 页码
 }
 
-type ReportReceiveResponse struct {
-	Data []ReportInfo `json:"data"`
-}
+type ReportReceiveResponse = Page[ReportInfo]
 
 // ReportDetailRequest 05. 报告详情
 type ReportDetailRequest struct {
@@ -1 +1,153 @@
 package types
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ==================== 系统设置 ====================
+
+// SystemSetting 系统设置，开关类设置的取值一般为 open(开启) / close(关闭)
+type SystemSetting struct {
+	Reg            string       `json:"reg,omitempty"`               // 注册: open(开放注册), invite(邀请码注册), close(关闭注册)
+	RegIdentity    string       `json:"reg_identity,omitempty"`      // 注册身份: normal(正常帐号), temp(临时帐号)
+	RegInvite      string       `json:"reg_invite,omitempty"`        // 注册邀请码
+	LoginCode      string       `json:"login_code,omitempty"`        // 登录验证码: auto(自动), open(开启), close(关闭)
+	PasswordPolicy string       `json:"password_policy,omitempty"`   // 密码策略: simple(简单), complex(复杂)
+	ProjectInvite  string       `json:"project_invite,omitempty"`    // 项目邀请链接: open, close
+	ChatInfo       string       `json:"chat_information,omitempty"`  // 聊天资料: required(必填), optional(选填)
+	AnonMessage    string       `json:"anon_message,omitempty"`      // 匿名消息: open, close
+	Voice          string       `json:"voice,omitempty"`             // 语音消息: open, close
+	E2EMessage     string       `json:"e2e_message,omitempty"`       // 端到端加密消息: open, close
+	AutoArchived   string       `json:"auto_archived,omitempty"`     // 自动归档已完成任务: open, close
+	ArchivedDay    NumberString `json:"archived_day,omitempty"`      // 完成多少天后自动归档
+	TaskVisible    string       `json:"task_visible,omitempty"`      // 任务可见性: open(项目成员可见), close(仅负责人及协助人可见)
+	AllGroupMute   string       `json:"all_group_mute,omitempty"`    // 全员群禁言: open(允许发言), user(仅管理员), close(全员禁言)
+	AllGroupAutoin string       `json:"all_group_autoin,omitempty"`  // 新会员自动加入全员群: yes, no
+	ImageCompress  string       `json:"image_compress,omitempty"`    // 压缩上传的图片: open, close
+	ImageSaveLocal string       `json:"image_save_local,omitempty"`  // 保存外链图片到本地: open, close
+	StartHome      string       `json:"start_home,omitempty"`        // 启用首页: open, close
+	HomeFooter     string       `json:"home_footer,omitempty"`       // 首页底部内容
+	FileUploadMax  NumberString `json:"file_upload_limit,omitempty"` // 文件上传大小限制(MB)，0 表示不限制
+}
+
+// SystemFeatures 由系统设置归纳的功能开关，便于工具按实例配置调整行为
+type SystemFeatures struct {
+	Registration     bool // 允许注册（含邀请码注册）
+	InviteOnly       bool // 仅允许邀请码注册
+	LoginCode        bool // 登录总是需要验证码
+	ComplexPassword  bool // 要求复杂密码
+	ProjectInvite    bool // 允许项目邀请链接
+	AnonMessage      bool // 允许匿名消息
+	VoiceMessage     bool // 允许语音消息
+	E2EMessage       bool // 启用端到端加密消息
+	AutoArchive      bool // 自动归档已完成任务
+	AllGroupMuted    bool // 全员群禁言（含仅管理员可发言）
+	AllGroupAutoJoin bool // 新会员自动加入全员群
+}
+
+// Features 返回设置对应的功能开关
+func (s *SystemSetting) Features() SystemFeatures {
+	return SystemFeatures{
+		Registration:     s.Reg == "open" || s.Reg == "invite",
+		InviteOnly:       s.Reg == "invite",
+		LoginCode:        s.LoginCode == "open",
+		ComplexPassword:  s.PasswordPolicy == "complex",
+		ProjectInvite:    s.ProjectInvite == "open",
+		AnonMessage:      s.AnonMessage == "open",
+		VoiceMessage:     s.Voice != "close",
+		E2EMessage:       s.E2EMessage == "open",
+		AutoArchive:      s.AutoArchived == "open",
+		AllGroupMuted:    s.AllGroupMute == "user" || s.AllGroupMute == "close",
+		AllGroupAutoJoin: s.AllGroupAutoin == "yes",
+	}
+}
+
+// SystemSettingRequest 01. 获取、保存系统设置
+type SystemSettingRequest struct {
+	Type string `json:"type"` // 操作类型: get(获取), save(保存，管理员)
+}
+
+// ==================== 任务优先级 ====================
+
+// SystemPriority 任务优先级定义
+type SystemPriority struct {
+	Name     string `json:"name"`     // 名称，如 "重要且紧急"
+	Color    string `json:"color"`    // 颜色，如 "#ED4014"
+	Days     int    `json:"days"`     // 新建任务时默认的完成天数，0 表示不设置
+	Priority int    `json:"priority"` // 优先级，数值越小越优先
+}
+
+// SystemPriorityRequest 02. 获取、保存任务优先级
+type SystemPriorityRequest struct {
+	Type string           `json:"type"`           // 操作类型: get(获取), save(保存，管理员)
+	List []SystemPriority `json:"list,omitempty"` // 保存时的优先级列表
+}
+
+// ==================== 列表模板 ====================
+
+// SystemColumnTemplate 新建项目时可选的任务列表模板
+type SystemColumnTemplate struct {
+	Name    string   `json:"name"`    // 模板名称
+	Columns []string `json:"columns"` // 任务列表名称
+}
+
+// SystemColumnTemplateRequest 03. 获取、保存列表模板
+type SystemColumnTemplateRequest struct {
+	Type string                 `json:"type"`           // 操作类型: get(获取), save(保存，管理员)
+	List []SystemColumnTemplate `json:"list,omitempty"` // 保存时的模板列表
+}
+
+// ==================== 版本信息 ====================
+
+// SystemVersion 服务器版本信息
+type SystemVersion struct {
+	Version string                 `json:"version"`           // 版本号，如 "0.40.12"
+	Publish map[string]interface{} `json:"publish,omitempty"` // 发布渠道信息
+}
+
+// AtLeast 报告服务器版本是否不低于 version（按点分隔的数字逐段比较，如 "0.39"）。
+// 版本号无法解析时返回 false
+func (v *SystemVersion) AtLeast(version string) bool {
+	have, ok := parseVersion(v.Version)
+	if !ok {
+		return false
+	}
+	want, ok := parseVersion(version)
+	if !ok {
+		return false
+	}
+	for i := range max(len(have), len(want)) {
+		var h, w int
+		if i < len(have) {
+			h = have[i]
+		}
+		if i < len(want) {
+			w = want[i]
+		}
+		if h != w {
+			return h > w
+		}
+	}
+	return true
+}
+
+// parseVersion 解析 "v1.2.3"、"1.2.3-beta" 形式的版本号
+func parseVersion(s string) ([]int, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(s, "-+ "); i >= 0 {
+		s = s[:i]
+	}
+	if s == "" {
+		return nil, false
+	}
+	var parts []int
+	for _, p := range strings.Split(s, ".") {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, false
+		}
+		parts = append(parts, n)
+	}
+	return parts, true
+}
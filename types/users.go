@@ -1 +1,262 @@
 package types
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+)
+
+// ==================== 用户信息 ====================
+
+// UserInfo 用户详细信息，登录、获取当前用户及修改资料时返回
+type UserInfo struct {
+	UserID           int       `json:"userid"`
+	Identity         []string  `json:"identity"`                  // 身份: admin(管理员), disable(禁用), temp(临时帐号) 等
+	Department       []int     `json:"department"`                // 所属部门ID
+	Az               string    `json:"az"`                        // 昵称首字母
+	Pinyin           string    `json:"pinyin"`                    // 昵称拼音
+	Email            string    `json:"email"`                     // 邮箱
+	Tel              string    `json:"tel"`                       // 电话
+	Nickname         string    `json:"nickname"`                  // 昵称
+	NicknameOriginal string    `json:"nickname_original"`         // 原始昵称（未设置时为空）
+	Profession       string    `json:"profession"`                // 职位/职称
+	Birthday         *string   `json:"birthday,omitempty"`        // 生日，格式: YYYY-MM-DD
+	Address          string    `json:"address"`                   // 地址
+	Introduction     string    `json:"introduction"`              // 个人简介
+	Userimg          string    `json:"userimg"`                   // 头像地址
+	UserimgOriginal  string    `json:"userimg_original"`          // 原始头像地址（未设置时为空）
+	Bot              int       `json:"bot"`                       // 是否机器人
+	Changepass       int       `json:"changepass"`                // 是否需要修改密码
+	LoginNum         int       `json:"login_num"`                 // 登录次数
+	TaskDialogID     int       `json:"task_dialog_id"`            // 最后打开的任务会话ID
+	CreatedIP        string    `json:"created_ip"`                // 注册IP
+	LastIP           string    `json:"last_ip"`                   // 最后登录IP
+	LastAt           *DateTime `json:"last_at,omitempty"`         // 最后登录时间
+	LineIP           string    `json:"line_ip"`                   // 最后在线IP
+	LineAt           *DateTime `json:"line_at,omitempty"`         // 最后在线时间
+	DisableAt        *DateTime `json:"disable_at,omitempty"`      // 禁用时间，未禁用时为空
+	CreatedAt        *DateTime `json:"created_at,omitempty"`      // 注册时间
+	UpdatedAt        *DateTime `json:"updated_at,omitempty"`      // 更新时间
+	Token            string    `json:"token,omitempty"`           // 认证令牌，仅登录时返回
+	DepartmentName   string    `json:"department_name,omitempty"` // 部门名称
+}
+
+// IsAdmin 是否为管理员
+func (u *UserInfo) IsAdmin() bool {
+	return slices.Contains(u.Identity, "admin")
+}
+
+// IsDisabled 是否已被禁用（离职）
+func (u *UserInfo) IsDisabled() bool {
+	return u.DisableAt != nil || slices.Contains(u.Identity, "disable")
+}
+
+// UserBasic 用户基础信息，搜索会员及批量获取基础信息时返回
+type UserBasic struct {
+	UserID       int       `json:"userid"`
+	Email        string    `json:"email"`                  // 邮箱
+	Nickname     string    `json:"nickname"`               // 昵称
+	Profession   string    `json:"profession"`             // 职位/职称
+	Userimg      string    `json:"userimg"`                // 头像地址
+	Az           string    `json:"az"`                     // 昵称首字母
+	Pinyin       string    `json:"pinyin"`                 // 昵称拼音
+	Bot          int       `json:"bot"`                    // 是否机器人
+	Online       bool      `json:"online"`                 // 是否在线
+	Identity     []string  `json:"identity,omitempty"`     // 身份
+	Department   []int     `json:"department,omitempty"`   // 所属部门ID
+	Introduction string    `json:"introduction,omitempty"` // 个人简介
+	LineAt       *DateTime `json:"line_at,omitempty"`      // 最后在线时间
+	DisableAt    *DateTime `json:"disable_at,omitempty"`   // 禁用时间，未禁用时为空
+	DeleteAt     *DateTime `json:"delete_at,omitempty"`    // 删除时间，未删除时为空
+}
+
+// ==================== 登录与认证 ====================
+
+// UserLoginRequest 01. 登录、注册
+type UserLoginRequest struct {
+	Type     string `json:"type,omitempty"`   // 类型: login(登录，默认), reg(注册)
+	Email    string `json:"email"`            // 邮箱
+	Password string `json:"password"`         // 密码
+	Code     string `json:"code,omitempty"`   // 登录验证码（多次失败后需要）
+	Invite   string `json:"invite,omitempty"` // 注册邀请码
+}
+
+// ==================== 会员查询 ====================
+
+// UserSearchRequest 06. 搜索会员列表
+type UserSearchRequest struct {
+	Key         string `json:"keys[key],omitempty"`           // 搜索关键词（邮箱、昵称、职位）
+	Disable     int    `json:"keys[disable],omitempty"`       // 0 排除已禁用(默认), 1 仅已禁用, 2 包含已禁用
+	Bot         int    `json:"keys[bot],omitempty"`           // 0 排除机器人(默认), 1 仅机器人, 2 包含机器人
+	ProjectID   int    `json:"keys[project_id],omitempty"`    // 仅该项目的成员
+	NoProjectID int    `json:"keys[no_project_id],omitempty"` // 排除该项目的成员
+	DialogID    int    `json:"keys[dialog_id],omitempty"`     // 仅该会话的成员
+	SortAz      string `json:"sorts[az],omitempty"`           // 按首字母排序: asc, desc
+	Department  int    `json:"keys[department],omitempty"`    // 仅该部门的成员
+	UpdatedTime int64  `json:"updated_time,omitempty"`        // 仅返回该时间戳之后更新的会员
+	Take        int    `json:"take,omitempty"`                // 获取数量，默认10，最大100
+}
+
+// UserBasicRequest 07. 获取指定会员基础信息
+type UserBasicRequest struct {
+	UserIDs []int `json:"userid"` // 会员ID列表
+}
+
+// ==================== 个人资料 ====================
+
+// UserEditRequest 04. 修改自己的资料，未设置的字段保持不变
+type UserEditRequest struct {
+	Userimg      *string `json:"userimg,omitempty"`      // 头像地址
+	Nickname     *string `json:"nickname,omitempty"`     // 昵称
+	Tel          *string `json:"tel,omitempty"`          // 电话
+	Profession   *string `json:"profession,omitempty"`   // 职位/职称
+	Birthday     *string `json:"birthday,omitempty"`     // 生日，格式: YYYY-MM-DD
+	Address      *string `json:"address,omitempty"`      // 地址
+	Introduction *string `json:"introduction,omitempty"` // 个人简介
+}
+
+// UserPasswordRequest 05. 修改自己的密码
+type UserPasswordRequest struct {
+	OldPass string `json:"oldpass"` // 旧密码
+	NewPass string `json:"newpass"` // 新密码
+}
+
+// ==================== 部门 ====================
+
+// Department 部门，DepartmentTree 返回时 Children 为子部门
+type Department struct {
+	ID          int           `json:"id"`
+	Name        string        `json:"name"`                 // 部门名称
+	DialogID    int           `json:"dialog_id"`            // 部门群聊ID
+	ParentID    int           `json:"parent_id"`            // 上级部门ID，顶级部门为0
+	OwnerUserID int           `json:"owner_userid"`         // 部门负责人ID
+	CreatedAt   *DateTime     `json:"created_at,omitempty"` // 创建时间
+	UpdatedAt   *DateTime     `json:"updated_at,omitempty"` // 更新时间
+	Children    []*Department `json:"children,omitempty"`   // 子部门
+}
+
+// UserDepartmentAddRequest 09. 新建、修改部门
+type UserDepartmentAddRequest struct {
+	ID          int    `json:"id,omitempty"`           // 部门ID，修改时传入
+	Name        string `json:"name"`                   // 部门名称
+	ParentID    int    `json:"parent_id,omitempty"`    // 上级部门ID
+	OwnerUserID int    `json:"owner_userid"`           // 部门负责人ID
+	DialogGroup string `json:"dialog_group,omitempty"` // 部门群聊: new(新建，默认), use(使用已有群聊)
+	DialogUseID int    `json:"dialog_useid,omitempty"` // 使用已有群聊时的群聊ID
+}
+
+// UserDepartmentDelRequest 10. 删除部门
+type UserDepartmentDelRequest struct {
+	ID int `json:"id"` // 部门ID
+}
+
+// ==================== 会员管理（管理员） ====================
+
+// 会员操作类型，用于 UserOperationRequest.Type
+const (
+	UserOpSetAdmin     = "setadmin"     // 设为管理员
+	UserOpClearAdmin   = "clearadmin"   // 取消管理员
+	UserOpSetTemp      = "settemp"      // 设为临时帐号
+	UserOpClearTemp    = "cleartemp"    // 取消临时帐号
+	UserOpDepartment   = "department"   // 设置所属部门
+	UserOpSetDisable   = "setdisable"   // 禁用（离职）
+	UserOpClearDisable = "cleardisable" // 启用（恢复在职）
+	UserOpDelete       = "delete"       // 删除
+)
+
+// UserOperationRequest 11. 操作会员（管理员）
+// Type 为空时仅修改设置了的资料字段
+type UserOperationRequest struct {
+	UserID         int    `json:"userid"`                    // 会员ID
+	Type           string `json:"type,omitempty"`            // 操作类型，见 UserOp* 常量
+	Department     []int  `json:"department,omitempty"`      // 所属部门ID，Type 为 department 时使用，不能为空
+	DisableTime    string `json:"disable_time,omitempty"`    // 离职时间，格式: YYYY-MM-DD HH:mm:ss，Type 为 setdisable 时使用
+	TransferUserID int    `json:"transfer_userid,omitempty"` // 交接人ID，禁用时将其负责的项目、任务移交给该会员
+	DeleteReason   string `json:"delete_reason,omitempty"`   // 删除原因，Type 为 delete 时使用
+
+	Email      string `json:"email,omitempty"`      // 修改邮箱
+	Tel        string `json:"tel,omitempty"`        // 修改电话
+	Nickname   string `json:"nickname,omitempty"`   // 修改昵称
+	Profession string `json:"profession,omitempty"` // 修改职位/职称
+	Password   string `json:"password,omitempty"`   // 重置密码，会员下次登录后需修改密码
+}
+
+// UserListsRequest 12. 会员列表（管理员）
+type UserListsRequest struct {
+	Key        string `json:"keys[key],omitempty"`        // 搜索关键词（邮箱、昵称、职位）
+	Identity   string `json:"keys[identity],omitempty"`   // 身份: admin(管理员), noadmin(非管理员), temp(临时帐号), notemp(非临时帐号)
+	Disable    string `json:"keys[disable],omitempty"`    // 离职: yes(仅离职), all(全部)，默认仅在职
+	Department int    `json:"keys[department],omitempty"` // 仅该部门的成员
+	Page       int    `json:"page,omitempty"`             // 页码
+	Pagesize   int    `json:"pagesize,omitempty"`         // 每页数量，默认20，最大100
+}
+
+type UserListsResponse = Page[UserInfo]
+
+// UserImportItem 待创建的会员
+type UserImportItem struct {
+	Email      string `json:"email"`                // 邮箱
+	Password   string `json:"password"`             // 初始密码
+	Nickname   string `json:"nickname,omitempty"`   // 昵称
+	Profession string `json:"profession,omitempty"` // 职位/职称
+	Tel        string `json:"tel,omitempty"`        // 电话
+	Department []int  `json:"department,omitempty"` // 所属部门ID
+}
+
+// UserImportRequest 13. 创建会员（管理员）
+type UserImportRequest struct {
+	List []UserImportItem `json:"list"` // 待创建的会员
+}
+
+// SkipChildren 由 WalkDepartments 的回调返回，表示跳过当前部门的子部门
+var SkipChildren = errors.New("skip children")
+
+// BuildDepartmentTree 将部门列表按 ParentID 组织为树，返回顶级部门。
+// 上级部门不在列表中的部门也作为顶级部门返回；同级部门按 ID 排序
+func BuildDepartmentTree(list []Department) []*Department {
+	nodes := make(map[int]*Department, len(list))
+	for i := range list {
+		d := list[i]
+		d.Children = nil
+		nodes[d.ID] = &d
+	}
+
+	var roots []*Department
+	for _, d := range nodes {
+		if parent, ok := nodes[d.ParentID]; ok && d.ParentID != d.ID {
+			parent.Children = append(parent.Children, d)
+		} else {
+			roots = append(roots, d)
+		}
+	}
+
+	byID := func(a, b *Department) int { return cmp.Compare(a.ID, b.ID) }
+	for _, d := range nodes {
+		slices.SortFunc(d.Children, byID)
+	}
+	slices.SortFunc(roots, byID)
+	return roots
+}
+
+// WalkDepartments 深度优先遍历部门树，对每个部门调用 fn，parents 为从顶级部门到
+// 其上级部门的路径。fn 返回 SkipChildren 时跳过该部门的子部门，返回其他错误时
+// 停止遍历并返回该错误
+func WalkDepartments(roots []*Department, fn func(d *Department, parents []*Department) error) error {
+	var walk func(nodes []*Department, parents []*Department) error
+	walk = func(nodes []*Department, parents []*Department) error {
+		for _, d := range nodes {
+			err := fn(d, parents)
+			if err == SkipChildren {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if err := walk(d.Children, append(parents[:len(parents):len(parents)], d)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return walk(roots, nil)
+}